@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Values of AnalyticsDbSyncStatusResponse.Status.
+const (
+	AnalyticsDbSyncCompleted  = "COMPLETED"
+	AnalyticsDbSyncInProgress = "IN_PROGRESS"
+	AnalyticsDbSyncFailed     = "FAILED"
+)
+
+// The state of the sync of a template to the analytics DB (the columns of analyticsDbConfiguration).
+type AnalyticsDbSyncStatusResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Older BioT versions do not expose the sync status, in that case SpecificErrorCodes.NotFound is returned (also for
+// 405 / 501, like the template validation endpoints).
+func (biotSdkImpl biotSdkImpl) GetAnalyticsDbSyncStatus(ctx context.Context, accessToken string, templateID string) (AnalyticsDbSyncStatusResponse, error) {
+	var url = fmt.Sprintf("%s/%s/v1/templates/%s/analytics-db/sync-status", biotSdkImpl.baseUrl, settingsPrefix, templateID)
+
+	httpResponse, err := biotSdkImpl.crudTemplateHelper(ctx, accessToken, url, http.MethodGet, nil)
+	if apiError, ok := ConvertAPIError(err); ok && (apiError.StatusCode == http.StatusMethodNotAllowed || apiError.StatusCode == http.StatusNotImplemented) {
+		return AnalyticsDbSyncStatusResponse{}, SpecificErrorCodes.NotFound
+	}
+	if err != nil {
+		return AnalyticsDbSyncStatusResponse{}, err
+	}
+	defer httpResponse.Body.Close()
+
+	var syncStatus AnalyticsDbSyncStatusResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&syncStatus); err != nil {
+		return AnalyticsDbSyncStatusResponse{}, err
+	}
+
+	return syncStatus, nil
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"crypto/hkdf"
+	"crypto/sha256"
 	"fmt"
 )
 
@@ -70,6 +72,10 @@ func (apiClient APIClient) GetTemplateByTypeAndName(ctx context.Context, entityT
 		return TemplateResponse{}, err
 	}
 
+	if response.Metadata.Page.TotalResults == 0 {
+		return TemplateResponse{}, fmt.Errorf("template with name=%q and type=%q: %w", templateName, entityType, SpecificErrorCodes.NotFound)
+	}
+
 	if response.Metadata.Page.TotalResults != 1 {
 		return TemplateResponse{}, fmt.Errorf(
 			"unexpected number of results for template with name=%q and type=%q: expected 1, got %d",
@@ -80,6 +86,48 @@ func (apiClient APIClient) GetTemplateByTypeAndName(ctx context.Context, entityT
 	return response.Data[0], nil
 }
 
+// The number of templates requested per page by ListTemplates.
+const listTemplatesPageSize = 100
+
+// ListTemplates returns the templates of the given entity type (of every entity type when it is empty),
+// page by page, stopping after limit templates when limit is positive.
+func (apiClient *APIClient) ListTemplates(ctx context.Context, entityType string, limit int) ([]TemplateResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	filter := map[string]interface{}{}
+	if entityType != "" {
+		filter["entityTypeName"] = map[string]interface{}{
+			"in": []string{entityType},
+		}
+	}
+
+	templates := []TemplateResponse{}
+	for page := 0; ; page++ {
+		searchRequest := map[string]interface{}{
+			"filter": filter,
+			"limit":  listTemplatesPageSize,
+			"page":   page,
+		}
+
+		response, err := apiClient.BiotSdk.SearchTemplates(ctx, token, searchRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, response.Data...)
+		if limit > 0 && len(templates) >= limit {
+			return templates[:limit], nil
+		}
+		if len(response.Data) == 0 || len(templates) >= response.Metadata.Page.TotalResults {
+			return templates, nil
+		}
+	}
+}
+
 func (apiClient *APIClient) UpdateTemplate(ctx context.Context, id string, req UpdateTemplateRequest, force bool) (TemplateResponse, error) {
 	token, err := apiClient.authenticator.GetAccessToken(ctx)
 
@@ -104,3 +152,296 @@ func (apiClient *APIClient) DeleteTemplate(ctx context.Context, id string) error
 
 	return apiClient.BiotSdk.DeleteTemplate(ctx, token, id)
 }
+
+func (apiClient *APIClient) ValidateCreateTemplate(ctx context.Context, req CreateTemplateRequest) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.ValidateCreateTemplate(ctx, token, req)
+}
+
+func (apiClient *APIClient) ValidateUpdateTemplate(ctx context.Context, id string, req UpdateTemplateRequest) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.ValidateUpdateTemplate(ctx, token, id, req)
+}
+
+func (apiClient *APIClient) GetAnalyticsDbSyncStatus(ctx context.Context, templateID string) (AnalyticsDbSyncStatusResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return AnalyticsDbSyncStatusResponse{}, err
+	}
+
+	return apiClient.BiotSdk.GetAnalyticsDbSyncStatus(ctx, token, templateID)
+}
+
+// CountEntities returns the number of entities of the given type that match the search filter.
+func (apiClient *APIClient) CountEntities(ctx context.Context, entityType string, filter map[string]interface{}) (int, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	searchRequest := map[string]interface{}{
+		"filter": filter,
+		"limit":  1,
+	}
+
+	response, err := apiClient.BiotSdk.SearchEntities(ctx, token, entityType, searchRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	return response.Metadata.Page.TotalResults, nil
+}
+
+// FindEntityID returns the ID of the single entity of the given type that matches the search filter.
+func (apiClient *APIClient) FindEntityID(ctx context.Context, entityType string, filter map[string]interface{}) (string, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	searchRequest := map[string]interface{}{
+		"filter": filter,
+		"limit":  2,
+	}
+
+	response, err := apiClient.BiotSdk.SearchEntities(ctx, token, entityType, searchRequest)
+	if err != nil {
+		return "", err
+	}
+
+	if response.Metadata.Page.TotalResults != 1 || len(response.Data) != 1 {
+		return "", fmt.Errorf("unexpected number of %s entities matching the search: expected 1, got %d", entityType, response.Metadata.Page.TotalResults)
+	}
+
+	id, _ := response.Data[0]["_id"].(string)
+	return id, nil
+}
+
+func (apiClient *APIClient) CreateOrganization(ctx context.Context, req OrganizationRequest) (OrganizationResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return OrganizationResponse{}, err
+	}
+
+	return apiClient.BiotSdk.CreateOrganization(ctx, token, req)
+}
+
+func (apiClient *APIClient) GetOrganization(ctx context.Context, id string) (OrganizationResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return OrganizationResponse{}, err
+	}
+
+	return apiClient.BiotSdk.GetOrganization(ctx, token, id)
+}
+
+func (apiClient *APIClient) UpdateOrganization(ctx context.Context, id string, req OrganizationRequest) (OrganizationResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return OrganizationResponse{}, err
+	}
+
+	return apiClient.BiotSdk.UpdateOrganization(ctx, token, id, req)
+}
+
+func (apiClient *APIClient) DeleteOrganization(ctx context.Context, id string) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.DeleteOrganization(ctx, token, id)
+}
+
+func (apiClient *APIClient) CreateUser(ctx context.Context, entityType string, req UserRequest, sendInvitation bool) (UserResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	return apiClient.BiotSdk.CreateUser(ctx, token, entityType, req, sendInvitation)
+}
+
+func (apiClient *APIClient) GetUser(ctx context.Context, entityType string, id string) (UserResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	return apiClient.BiotSdk.GetUser(ctx, token, entityType, id)
+}
+
+func (apiClient *APIClient) UpdateUser(ctx context.Context, entityType string, id string, req UserRequest) (UserResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	return apiClient.BiotSdk.UpdateUser(ctx, token, entityType, id, req)
+}
+
+func (apiClient *APIClient) DeleteUser(ctx context.Context, entityType string, id string) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.DeleteUser(ctx, token, entityType, id)
+}
+
+func (apiClient *APIClient) CreateService(ctx context.Context, request ServiceRequest) (ServiceResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return ServiceResponse{}, err
+	}
+
+	return apiClient.BiotSdk.CreateService(ctx, token, request)
+}
+
+func (apiClient *APIClient) GetService(ctx context.Context, id string) (ServiceResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return ServiceResponse{}, err
+	}
+
+	return apiClient.BiotSdk.GetService(ctx, token, id)
+}
+
+func (apiClient *APIClient) UpdateService(ctx context.Context, id string, request ServiceRequest) (ServiceResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return ServiceResponse{}, err
+	}
+
+	return apiClient.BiotSdk.UpdateService(ctx, token, id, request)
+}
+
+func (apiClient *APIClient) DeleteService(ctx context.Context, id string) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.DeleteService(ctx, token, id)
+}
+
+func (apiClient *APIClient) SetServiceSecret(ctx context.Context, id string, secretKey string) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.SetServiceSecret(ctx, token, id, ServiceSecretRequest{SecretKey: secretKey})
+}
+
+// DeriveKey derives keyLength bytes for info from the secret key the provider logs in with (HKDF-SHA256): the same info
+// always gives the same bytes, and they do not reveal the secret key.
+func (apiClient *APIClient) DeriveKey(info string, keyLength int) ([]byte, error) {
+	return hkdf.Key(sha256.New, []byte(apiClient.authenticator.serviceSecretKey), nil, info, keyLength)
+}
+
+func (apiClient *APIClient) CreateRole(ctx context.Context, req RoleRequest) (RoleResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return RoleResponse{}, err
+	}
+
+	return apiClient.BiotSdk.CreateRole(ctx, token, req)
+}
+
+func (apiClient *APIClient) GetRole(ctx context.Context, id string) (RoleResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return RoleResponse{}, err
+	}
+
+	return apiClient.BiotSdk.GetRole(ctx, token, id)
+}
+
+func (apiClient *APIClient) UpdateRole(ctx context.Context, id string, req RoleRequest) (RoleResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return RoleResponse{}, err
+	}
+
+	return apiClient.BiotSdk.UpdateRole(ctx, token, id, req)
+}
+
+func (apiClient *APIClient) DeleteRole(ctx context.Context, id string) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.DeleteRole(ctx, token, id)
+}
+
+func (apiClient *APIClient) GetRoleAssignments(ctx context.Context, roleID string) (RoleAssignmentsResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return RoleAssignmentsResponse{}, err
+	}
+
+	return apiClient.BiotSdk.GetRoleAssignments(ctx, token, roleID)
+}
+
+func (apiClient *APIClient) AssignRole(ctx context.Context, roleID string, assignment RoleAssignment) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.AssignRole(ctx, token, roleID, assignment)
+}
+
+func (apiClient *APIClient) UnassignRole(ctx context.Context, roleID string, principalID string) error {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return apiClient.BiotSdk.UnassignRole(ctx, token, roleID, principalID)
+}
+
+func (apiClient *APIClient) GetPermissions(ctx context.Context) (PermissionsResponse, error) {
+	token, err := apiClient.authenticator.GetAccessToken(ctx)
+
+	if err != nil {
+		return PermissionsResponse{}, err
+	}
+
+	return apiClient.BiotSdk.GetPermissions(ctx, token)
+}
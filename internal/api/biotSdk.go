@@ -28,14 +28,16 @@ type LoginResponse struct {
 
 /* Errors: */
 type errorCodesStruct struct {
-	NotFound       error
-	Unauthorized   error
-	InvalidRequest error
+	NotFound              error
+	Unauthorized          error
+	InvalidRequest        error
+	ValidationUnavailable error
 	// Add more as needed
 }
 
 var SpecificErrorCodes = errorCodesStruct{
-	NotFound: errors.New("resource not found"),
+	NotFound:              errors.New("resource not found"),
+	ValidationUnavailable: errors.New("template validation endpoint is not available"),
 }
 
 type APIError BiotError
@@ -65,7 +67,32 @@ type BiotSdk interface {
 	GetTemplate(ctx context.Context, token string, id string) (TemplateResponse, error)
 	DeleteTemplate(ctx context.Context, accessToken string, id string) error
 	SearchTemplates(ctx context.Context, token string, searchrequest map[string]interface{}) (SearchTemplatesResponse, error)
+	SearchEntities(ctx context.Context, accessToken string, entityType string, searchRequest map[string]interface{}) (SearchEntitiesResponse, error)
+	GetAnalyticsDbSyncStatus(ctx context.Context, accessToken string, templateID string) (AnalyticsDbSyncStatusResponse, error)
+	ValidateCreateTemplate(ctx context.Context, accessToken string, request CreateTemplateRequest) error
+	ValidateUpdateTemplate(ctx context.Context, accessToken string, id string, request UpdateTemplateRequest) error
 	ValidateVersions(ctx context.Context, accessToken string, terraformProviderVersion string, minimumBiotVersion string) (TerraformVersionValidationResponse, error)
+	CreateOrganization(ctx context.Context, accessToken string, request OrganizationRequest) (OrganizationResponse, error)
+	GetOrganization(ctx context.Context, accessToken string, id string) (OrganizationResponse, error)
+	UpdateOrganization(ctx context.Context, accessToken string, id string, request OrganizationRequest) (OrganizationResponse, error)
+	DeleteOrganization(ctx context.Context, accessToken string, id string) error
+	CreateUser(ctx context.Context, accessToken string, entityType string, request UserRequest, sendInvitation bool) (UserResponse, error)
+	GetUser(ctx context.Context, accessToken string, entityType string, id string) (UserResponse, error)
+	UpdateUser(ctx context.Context, accessToken string, entityType string, id string, request UserRequest) (UserResponse, error)
+	DeleteUser(ctx context.Context, accessToken string, entityType string, id string) error
+	CreateService(ctx context.Context, accessToken string, request ServiceRequest) (ServiceResponse, error)
+	GetService(ctx context.Context, accessToken string, id string) (ServiceResponse, error)
+	UpdateService(ctx context.Context, accessToken string, id string, request ServiceRequest) (ServiceResponse, error)
+	DeleteService(ctx context.Context, accessToken string, id string) error
+	SetServiceSecret(ctx context.Context, accessToken string, id string, request ServiceSecretRequest) error
+	CreateRole(ctx context.Context, accessToken string, request RoleRequest) (RoleResponse, error)
+	GetRole(ctx context.Context, accessToken string, id string) (RoleResponse, error)
+	UpdateRole(ctx context.Context, accessToken string, id string, request RoleRequest) (RoleResponse, error)
+	DeleteRole(ctx context.Context, accessToken string, id string) error
+	GetRoleAssignments(ctx context.Context, accessToken string, roleID string) (RoleAssignmentsResponse, error)
+	AssignRole(ctx context.Context, accessToken string, roleID string, assignment RoleAssignment) error
+	UnassignRole(ctx context.Context, accessToken string, roleID string, principalID string) error
+	GetPermissions(ctx context.Context, accessToken string) (PermissionsResponse, error)
 }
 
 const (
@@ -152,7 +179,11 @@ func (biotSdkImpl biotSdkImpl) UpdateTemplate(ctx context.Context, accessToken s
 		url += "?force=true"
 	}
 
-	jsonBody, _ := json.Marshal(request)
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return TemplateResponse{}, err
+	}
+
 	httpResponse, err := biotSdkImpl.crudTemplateHelper(ctx, accessToken, url, http.MethodPut, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return TemplateResponse{}, err
@@ -186,6 +217,63 @@ func (biotSdkImpl biotSdkImpl) DeleteTemplate(ctx context.Context, accessToken s
 	return err
 }
 
+func (biotSdkImpl biotSdkImpl) ValidateCreateTemplate(ctx context.Context, accessToken string, request CreateTemplateRequest) error {
+	var url = fmt.Sprintf("%s/%s/v1/templates/validate", biotSdkImpl.baseUrl, settingsPrefix)
+
+	jsonBody, _ := json.Marshal(request)
+
+	return biotSdkImpl.validateTemplateHelper(ctx, accessToken, url, bytes.NewBuffer(jsonBody))
+}
+
+func (biotSdkImpl biotSdkImpl) ValidateUpdateTemplate(ctx context.Context, accessToken string, id string, request UpdateTemplateRequest) error {
+	var url = fmt.Sprintf("%s/%s/v1/templates/%s/validate", biotSdkImpl.baseUrl, settingsPrefix, id)
+
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return biotSdkImpl.validateTemplateHelper(ctx, accessToken, url, bytes.NewBuffer(jsonBody))
+}
+
+// The validation endpoints perform a dry-run of the create / update request without persisting anything.
+// Older BioT versions do not expose them, in that case SpecificErrorCodes.ValidationUnavailable is returned
+// so the caller can fall back to local checks.
+func (biotSdkImpl biotSdkImpl) validateTemplateHelper(ctx context.Context, accessToken string, url string, body io.Reader) error {
+	req, requestErr := http.NewRequest(http.MethodPost, url, body)
+	if requestErr != nil {
+		return requestErr
+	}
+
+	req.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := httpClient.Do(req)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to call template validation API", map[string]interface{}{
+			"url":   url,
+			"error": err,
+		})
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	switch httpResponse.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		tflog.Debug(ctx, "Template validation API is not available", map[string]interface{}{
+			"url":         url,
+			"status_code": httpResponse.StatusCode,
+		})
+		return SpecificErrorCodes.ValidationUnavailable
+	}
+
+	if !isResponseOk(httpResponse) {
+		return parseAPIError(httpResponse)
+	}
+
+	return nil
+}
+
 // Using this function requires the user to close the httpResponse body (httpResponse.Body.Close()
 // Only in the cases where the response returned with status OK (200 / 201 / 2xx...)
 // In case of errors, the body will be closed within this funciton.
@@ -290,6 +378,7 @@ func isResponseOk(response *http.Response) bool {
 func parseAPIError(response *http.Response) error {
 	var apiError APIError
 	json.NewDecoder(response.Body).Decode(&apiError)
+	apiError.StatusCode = response.StatusCode
 
 	// Ensure required fields have defaults
 	if apiError.Message == "" {
@@ -306,7 +395,7 @@ func parseAPIError(response *http.Response) error {
 }
 
 func ConvertAPIError(err error) (apiError APIError, ok bool) {
-	apiError, ok = err.(APIError)
+	ok = errors.As(err, &apiError)
 	return apiError, ok
 }
 
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// BioT entities (organizations, users, ...) are flat JSON documents: the builtin attributes are prefixed with "_"
+// (e.g. "_name", "_templateId") and the custom attributes of the template are keyed by their name.
+
+type EntityName struct {
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+// A reference to another entity in an entity document, e.g. "_template" or "_ownerOrganization".
+type EntityReference struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Marshals the builtin attributes (a struct with "_" prefixed fields) and the custom attribute values into one document.
+// A nil custom attribute value is sent as null, which clears the attribute on update.
+func marshalEntityDocument(builtinAttributes interface{}, customAttributes map[string]interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(builtinAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, err
+	}
+
+	for name, value := range customAttributes {
+		document[name] = value
+	}
+
+	return json.Marshal(document)
+}
+
+// Sends a JSON request to an entity API and decodes the response into responseBody (when not nil).
+// A 404 response is returned as SpecificErrorCodes.NotFound.
+func (biotSdkImpl biotSdkImpl) entityRequest(ctx context.Context, accessToken string, method string, url string, requestBody interface{}, responseBody interface{}) error {
+	var body io.Reader
+	if requestBody != nil {
+		encoded, err := json.Marshal(requestBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(encoded)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", accessToken))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResponse, err := httpClient.Do(req)
+	if err != nil {
+		tflog.Error(ctx, "Failed to call entity API", map[string]interface{}{
+			"method": method,
+			"url":    url,
+			"error":  err,
+		})
+		return err
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return SpecificErrorCodes.NotFound
+	}
+
+	if !isResponseOk(httpResponse) {
+		tflog.Error(ctx, "Entity API returned error status", map[string]interface{}{
+			"method":      method,
+			"url":         url,
+			"status_code": httpResponse.StatusCode,
+		})
+		return parseAPIError(httpResponse)
+	}
+
+	if responseBody == nil {
+		return nil
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(responseBody)
+}
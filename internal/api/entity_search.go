@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// The search endpoint of the entities of each entity type (GET with a searchRequest query parameter).
+// Entity types that are not listed here can not be searched by the provider, their templates are deleted with a
+// warning instead of the entity count check.
+var entitySearchPaths = map[string]string{
+	"caregiver":         "organization/v1/users/caregivers",
+	"command":           "device/v1/devices/commands",
+	"device":            "device/v2/devices",
+	"device-alert":      "device/v1/devices/alerts",
+	"generic-entity":    "generic-entity/v1/generic-entities",
+	"organization":      "organization/v1/organizations",
+	"organization-user": "organization/v1/users/organizations",
+	"patient":           "organization/v1/users/patients",
+	"patient-alert":     "organization/v1/users/patients/alerts",
+	"registration-code": "device/v1/registration-codes",
+	"sensor":            "device/v1/devices/sensors",
+	"usage-session":     "device/v1/devices/usage-sessions",
+}
+
+type SearchEntitiesResponse struct {
+	Data     []map[string]interface{} `json:"data"`
+	Metadata SearchMetadata           `json:"metadata"`
+}
+
+func (biotSdkImpl biotSdkImpl) SearchEntities(ctx context.Context, accessToken string, entityType string, searchRequest map[string]interface{}) (SearchEntitiesResponse, error) {
+	searchPath, ok := entitySearchPaths[entityType]
+	if !ok {
+		return SearchEntitiesResponse{}, fmt.Errorf("searching entities of type [%s] is not supported", entityType)
+	}
+
+	encodedSearchRequest, err := encodeSearchRequest(searchRequest)
+	if err != nil {
+		return SearchEntitiesResponse{}, err
+	}
+
+	var url = fmt.Sprintf("%s/%s?searchRequest=%s", biotSdkImpl.baseUrl, searchPath, encodedSearchRequest)
+
+	req, requestErr := http.NewRequest(http.MethodGet, url, nil)
+	if requestErr != nil {
+		return SearchEntitiesResponse{}, requestErr
+	}
+
+	req.Header.Set(authorizationHeaderKey, fmt.Sprintf("Bearer %s", accessToken))
+
+	var httpResponse, responseErr = httpClient.Do(req)
+	if responseErr != nil {
+		return SearchEntitiesResponse{}, responseErr
+	}
+	defer httpResponse.Body.Close()
+
+	if !isResponseOk(httpResponse) {
+		return SearchEntitiesResponse{}, parseAPIError(httpResponse)
+	}
+
+	var searchEntitiesResponse SearchEntitiesResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&searchEntitiesResponse); err != nil {
+		return SearchEntitiesResponse{}, err
+	}
+
+	return searchEntitiesResponse, nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type OrganizationRequest struct {
+	Name        string  `json:"_name"`
+	Description *string `json:"_description"`
+	// Sent only on create, the template of an organization can not be changed.
+	TemplateID           string                       `json:"_templateId,omitempty"`
+	PrimaryAdministrator *PrimaryAdministratorRequest `json:"_primaryAdministrator,omitempty"`
+
+	// The values of the custom attributes of the template, keyed by attribute name.
+	CustomAttributes map[string]interface{} `json:"-"`
+}
+
+// The primary administrator is created with the organization.
+type PrimaryAdministratorRequest struct {
+	Name       EntityName `json:"_name"`
+	Email      string     `json:"_email"`
+	TemplateID string     `json:"_templateId,omitempty"`
+}
+
+type OrganizationResponse struct {
+	ID                   string           `json:"_id"`
+	Name                 string           `json:"_name"`
+	Description          *string          `json:"_description"`
+	Template             *EntityReference `json:"_template"`
+	PrimaryAdministrator *EntityReference `json:"_primaryAdministrator"`
+
+	// The whole organization document, for the values of the custom attributes (see UnmarshalJSON).
+	Document map[string]interface{} `json:"-"`
+}
+
+func (r OrganizationRequest) MarshalJSON() ([]byte, error) {
+	type organizationRequestAlias OrganizationRequest
+	return marshalEntityDocument(organizationRequestAlias(r), r.CustomAttributes)
+}
+
+func (r *OrganizationResponse) UnmarshalJSON(data []byte) error {
+	type organizationResponseAlias OrganizationResponse
+
+	var alias organizationResponseAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &alias.Document); err != nil {
+		return err
+	}
+
+	*r = OrganizationResponse(alias)
+	return nil
+}
+
+func (biotSdkImpl biotSdkImpl) organizationsURL(id string) string {
+	if id == "" {
+		return fmt.Sprintf("%s/organization/v1/organizations", biotSdkImpl.baseUrl)
+	}
+	return fmt.Sprintf("%s/organization/v1/organizations/%s", biotSdkImpl.baseUrl, url.PathEscape(id))
+}
+
+func (biotSdkImpl biotSdkImpl) CreateOrganization(ctx context.Context, accessToken string, request OrganizationRequest) (OrganizationResponse, error) {
+	var response OrganizationResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPost, biotSdkImpl.organizationsURL(""), request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) GetOrganization(ctx context.Context, accessToken string, id string) (OrganizationResponse, error) {
+	var response OrganizationResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodGet, biotSdkImpl.organizationsURL(id), nil, &response)
+	return response, err
+}
+
+// Only the fields that are set in the request are changed (PATCH).
+func (biotSdkImpl biotSdkImpl) UpdateOrganization(ctx context.Context, accessToken string, id string, request OrganizationRequest) (OrganizationResponse, error) {
+	var response OrganizationResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPatch, biotSdkImpl.organizationsURL(id), request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) DeleteOrganization(ctx context.Context, accessToken string, id string) error {
+	return biotSdkImpl.entityRequest(ctx, accessToken, http.MethodDelete, biotSdkImpl.organizationsURL(id), nil, nil)
+}
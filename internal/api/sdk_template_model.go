@@ -1,5 +1,7 @@
 package api
 
+import "encoding/json"
+
 type BaseTemplate struct {
 	DisplayName              string                    `json:"displayName"`
 	Name                     string                    `json:"name"`
@@ -8,12 +10,18 @@ type BaseTemplate struct {
 	AnalyticsDbConfiguration *AnalyticsDbConfiguration `json:"analyticsDbConfiguration"`
 }
 
+// The entity type and the parent template are set on create only, they are not part of the update.
 type UpdateTemplateRequest struct {
 	BaseTemplate
-	ParentTemplateID   *string                    `json:"parentTemplateId"`
 	BuiltInAttributes  []BuiltinAttributeRequest  `json:"builtInAttributes"`
 	CustomAttributes   []CustomAttributeRequest   `json:"customAttributes"`
 	TemplateAttributes []TemplateAttributeRequest `json:"templateAttributes"`
+
+	// The last template document read from BioT. When set, the request is merged over it (see MarshalJSON).
+	BaseDocument json.RawMessage `json:"-"`
+
+	// A raw template document (biot_template_json). When set, it is sent instead of the modeled fields.
+	Document json.RawMessage `json:"-"`
 }
 
 type CreateTemplateRequest struct {
@@ -23,6 +31,9 @@ type CreateTemplateRequest struct {
 	BuiltInAttributes  []BuiltinAttributeRequest  `json:"builtInAttributes"`
 	CustomAttributes   []CustomAttributeRequest   `json:"customAttributes"`
 	TemplateAttributes []TemplateAttributeRequest `json:"templateAttributes"`
+
+	// A raw template document (biot_template_json). When set, it is sent instead of the modeled fields.
+	Document json.RawMessage `json:"-"`
 }
 
 type TemplateResponse struct {
@@ -33,6 +44,9 @@ type TemplateResponse struct {
 	BuiltInAttributes  []BuiltinAttributeResponse  `json:"builtInAttributes"`
 	CustomAttributes   []CustomAttributeResponse   `json:"customAttributes"`
 	TemplateAttributes []TemplateAttributeResponse `json:"templateAttributes"`
+
+	// The template document exactly as returned by BioT (see UnmarshalJSON).
+	Raw json.RawMessage `json:"-"`
 }
 
 // ********* All below are for search: *****************
@@ -82,13 +96,14 @@ type BaseAttribute struct {
 
 type BuiltinAttributeRequest struct {
 	BaseAttribute
+	Category                 string                    `json:"category,omitempty"`
 	AnalyticsDbConfiguration *AnalyticsDbConfiguration `json:"analyticsDbConfiguration"`
 }
 
 type CustomAttributeRequest struct {
 	BaseAttribute
 	AnalyticsDbConfiguration *AnalyticsDbConfiguration `json:"analyticsDbConfiguration"`
-	Category                 string                    `json:"category"`
+	Category                 string                    `json:"category,omitempty"`
 }
 
 type BaseAttributeResponse struct {
@@ -168,6 +183,8 @@ type BiotError struct {
 	TraceID     string       `json:"traceId"`
 	Environment string       `json:"environment"`
 	Details     ErrorDetails `json:"details"`
+	// The HTTP status of the response, not part of the error body.
+	StatusCode int `json:"-"`
 }
 
 type ValidationMetadata struct {
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Fields that BioT returns in the template response but are not part of the create / update requests.
+var responseOnlyTemplateFields = []string{"id", "entityTypeName", "parentTemplate", "creationTime", "lastModifiedTime"}
+
+// Same as responseOnlyTemplateFields, for the attributes of the template.
+// "category" is an object in the response and a string in the request.
+var responseOnlyAttributeFields = []string{"validationMetadata", "organizationSelection", "category"}
+
+var templateAttributeCollections = []string{"builtInAttributes", "customAttributes", "templateAttributes"}
+
+// Modeled fields that can be left out of the request when they are not set (omitempty), keyed by the parent field
+// (the collection, for the fields of an attribute). The document values of these fields must not survive the merge,
+// otherwise they could never be removed.
+// Not listed: readOnly and validationMetadata are not set by the provider (the document value is the current one),
+// and the id of a selectable value is what it is matched by.
+var omittedModeledFields = map[string][]string{
+	"builtInAttributes":  {"category"},
+	"customAttributes":   {"category"},
+	"templateAttributes": {"organizationSelectionConfiguration"},
+	"validation":         {"mandatory", "defaultValue", "min", "max", "regex"},
+}
+
+// UnmarshalJSON keeps the raw template document next to the modeled fields,
+// so fields the provider does not model can be sent back to BioT on update.
+func (t *TemplateResponse) UnmarshalJSON(data []byte) error {
+	type templateResponseAlias TemplateResponse
+
+	var alias templateResponseAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	*t = TemplateResponse(alias)
+	t.Raw = append(json.RawMessage(nil), data...)
+
+	return nil
+}
+
+// MarshalJSON sends the raw Document, or else the modeled fields.
+func (r CreateTemplateRequest) MarshalJSON() ([]byte, error) {
+	type createTemplateRequestAlias CreateTemplateRequest
+
+	if len(r.Document) > 0 {
+		return r.Document, nil
+	}
+	return json.Marshal(createTemplateRequestAlias(r))
+}
+
+// MarshalJSON sends the modeled fields (or the raw Document) merged over BaseDocument (when set), so fields the
+// provider does not model keep their current values in BioT.
+func (r UpdateTemplateRequest) MarshalJSON() ([]byte, error) {
+	type updateTemplateRequestAlias UpdateTemplateRequest
+
+	modeled := r.Document
+	if len(modeled) == 0 {
+		var err error
+		modeled, err = json.Marshal(updateTemplateRequestAlias(r))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.BaseDocument) == 0 {
+		return modeled, nil
+	}
+
+	return MergeOverTemplateDocument(r.BaseDocument, modeled)
+}
+
+// MergeOverTemplateDocument merges a request over a template document (as returned by GET).
+// Values in the request always win, fields that exist only in the document are kept, except for response-only fields.
+// Attributes (and selectable values) are matched by id, or by name when there is no id. Attributes that are not in
+// the request are removed.
+func MergeOverTemplateDocument(document json.RawMessage, request json.RawMessage) (json.RawMessage, error) {
+	var base map[string]interface{}
+	if err := json.Unmarshal(document, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse template document: %w", err)
+	}
+
+	var overlay map[string]interface{}
+	if err := json.Unmarshal(request, &overlay); err != nil {
+		return nil, fmt.Errorf("failed to parse template request: %w", err)
+	}
+
+	StripResponseOnlyTemplateFields(base)
+
+	return json.Marshal(mergeJSONValues("", base, overlay))
+}
+
+// StripResponseOnlyTemplateFields removes (in place) the fields of a template document that can not be sent in a request.
+func StripResponseOnlyTemplateFields(document map[string]interface{}) {
+	for _, field := range responseOnlyTemplateFields {
+		delete(document, field)
+	}
+
+	for _, collection := range templateAttributeCollections {
+		attributes, _ := document[collection].([]interface{})
+		for _, attribute := range attributes {
+			if attributeObject, ok := attribute.(map[string]interface{}); ok {
+				for _, field := range responseOnlyAttributeFields {
+					delete(attributeObject, field)
+				}
+			}
+		}
+	}
+}
+
+// ToTemplateRequestDocument converts (in place) a template document as returned by GET, e.g. exported from the
+// console, to the request form. Response-only fields are removed, after their values are moved to the matching
+// request fields that the document does not set: entityTypeName to entityType, parentTemplate to parentTemplateId,
+// organizationSelection to organizationSelectionConfiguration, and the category object to its name.
+func ToTemplateRequestDocument(document map[string]interface{}) {
+	setIfMissing(document, "entityType", document["entityTypeName"])
+	if parent, ok := document["parentTemplate"].(map[string]interface{}); ok {
+		setIfMissing(document, "parentTemplateId", parent["id"])
+	}
+
+	for _, collection := range templateAttributeCollections {
+		attributes, _ := document[collection].([]interface{})
+		for _, attribute := range attributes {
+			attributeObject, ok := attribute.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if category, ok := attributeObject["category"].(map[string]interface{}); ok {
+				attributeObject["category"] = category["name"]
+			}
+			if selection, ok := attributeObject["organizationSelection"].(map[string]interface{}); ok {
+				setIfMissing(attributeObject, "organizationSelectionConfiguration", selection["configuration"])
+			}
+			delete(attributeObject, "validationMetadata")
+			delete(attributeObject, "organizationSelection")
+		}
+	}
+
+	for _, field := range responseOnlyTemplateFields {
+		delete(document, field)
+	}
+}
+
+func setIfMissing(object map[string]interface{}, field string, value interface{}) {
+	if _, exists := object[field]; !exists && value != nil {
+		object[field] = value
+	}
+}
+
+func mergeJSONValues(field string, base interface{}, overlay interface{}) interface{} {
+	switch overlayValue := overlay.(type) {
+	case map[string]interface{}:
+		baseObject, ok := base.(map[string]interface{})
+		if !ok {
+			return overlayValue
+		}
+
+		merged := make(map[string]interface{}, len(baseObject)+len(overlayValue))
+		for key, value := range baseObject {
+			merged[key] = value
+		}
+		for _, key := range omittedModeledFields[field] {
+			delete(merged, key)
+		}
+		for key, value := range overlayValue {
+			merged[key] = mergeJSONValues(key, baseObject[key], value)
+		}
+		return merged
+
+	case []interface{}:
+		baseArray, ok := base.([]interface{})
+		if !ok {
+			return overlayValue
+		}
+
+		merged := make([]interface{}, 0, len(overlayValue))
+		for _, element := range overlayValue {
+			if match := findMatchingElement(baseArray, element); match != nil {
+				merged = append(merged, mergeJSONValues(field, match, element))
+			} else {
+				merged = append(merged, element)
+			}
+		}
+		return merged
+
+	default:
+		return overlayValue
+	}
+}
+
+// Elements of attribute / selectable value arrays are objects identified by "id", or by "name" before they have an id.
+func findMatchingElement(elements []interface{}, element interface{}) interface{} {
+	elementObject, ok := element.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	for _, key := range []string{"id", "name"} {
+		value, ok := elementObject[key].(string)
+		if !ok || value == "" {
+			continue
+		}
+
+		for _, candidate := range elements {
+			if candidateObject, ok := candidate.(map[string]interface{}); ok && candidateObject[key] == value {
+				return candidateObject
+			}
+		}
+	}
+
+	return nil
+}
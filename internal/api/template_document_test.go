@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeOverTemplateDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		request  string
+		want     string
+	}{
+		{
+			name:     "fields that are not modeled are kept",
+			document: `{"name": "device", "displayName": "Device", "consoleSettings": {"icon": "heart"}}`,
+			request:  `{"name": "device", "displayName": "Device 2"}`,
+			want:     `{"name": "device", "displayName": "Device 2", "consoleSettings": {"icon": "heart"}}`,
+		},
+		{
+			name:     "response-only fields are removed",
+			document: `{"id": "1", "name": "device", "entityTypeName": "device", "parentTemplate": {"id": "2"}, "creationTime": "t", "lastModifiedTime": "t"}`,
+			request:  `{"name": "device"}`,
+			want:     `{"name": "device"}`,
+		},
+		{
+			name:     "attributes are matched by id and keep their fields that are not modeled",
+			document: `{"customAttributes": [{"id": "a1", "name": "weight", "displayName": "Weight", "uiSettings": {"hidden": true}, "validationMetadata": {}, "category": {"name": "REGULAR"}}]}`,
+			request:  `{"customAttributes": [{"id": "a1", "name": "mass", "displayName": "Mass", "category": "REGULAR"}]}`,
+			want:     `{"customAttributes": [{"id": "a1", "name": "mass", "displayName": "Mass", "uiSettings": {"hidden": true}, "category": "REGULAR"}]}`,
+		},
+		{
+			name:     "attributes without an id are matched by name",
+			document: `{"customAttributes": [{"id": "a1", "name": "weight", "uiSettings": {"hidden": true}}]}`,
+			request:  `{"customAttributes": [{"name": "weight", "displayName": "Weight"}]}`,
+			want:     `{"customAttributes": [{"id": "a1", "name": "weight", "displayName": "Weight", "uiSettings": {"hidden": true}}]}`,
+		},
+		{
+			name:     "attributes that are not in the request are removed",
+			document: `{"customAttributes": [{"id": "a1", "name": "weight"}, {"id": "a2", "name": "height"}]}`,
+			request:  `{"customAttributes": [{"id": "a1", "name": "weight"}]}`,
+			want:     `{"customAttributes": [{"id": "a1", "name": "weight"}]}`,
+		},
+		{
+			name:     "omitted modeled fields do not survive the merge",
+			document: `{"customAttributes": [{"id": "a1", "name": "weight", "validation": {"mandatory": true, "min": 1, "max": 5, "regex": "x", "defaultValue": "2", "custom": "kept"}}], "builtInAttributes": [{"id": "b1", "name": "_name", "category": {"name": "REGULAR"}}], "templateAttributes": [{"id": "t1", "name": "limit", "organizationSelectionConfiguration": {"all": true}}]}`,
+			request:  `{"customAttributes": [{"id": "a1", "name": "weight", "validation": {}}], "builtInAttributes": [{"id": "b1", "name": "_name"}], "templateAttributes": [{"id": "t1", "name": "limit"}]}`,
+			want:     `{"customAttributes": [{"id": "a1", "name": "weight", "validation": {"custom": "kept"}}], "builtInAttributes": [{"id": "b1", "name": "_name"}], "templateAttributes": [{"id": "t1", "name": "limit"}]}`,
+		},
+		{
+			name:     "an unset custom attribute category is left out",
+			document: `{"customAttributes": [{"id": "a1", "name": "weight", "category": {"name": "MEASUREMENT"}}]}`,
+			request:  `{"customAttributes": [{"id": "a1", "name": "weight"}]}`,
+			want:     `{"customAttributes": [{"id": "a1", "name": "weight"}]}`,
+		},
+		{
+			name:     "selectable values are matched by id",
+			document: `{"customAttributes": [{"id": "a1", "name": "color", "selectableValues": [{"id": "v1", "name": "red", "order": 3}]}]}`,
+			request:  `{"customAttributes": [{"id": "a1", "name": "color", "selectableValues": [{"id": "v1", "name": "dark_red"}, {"name": "blue"}]}]}`,
+			want:     `{"customAttributes": [{"id": "a1", "name": "color", "selectableValues": [{"id": "v1", "name": "dark_red", "order": 3}, {"name": "blue"}]}]}`,
+		},
+		{
+			name:     "null in the request wins",
+			document: `{"description": "old"}`,
+			request:  `{"description": null}`,
+			want:     `{"description": null}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := MergeOverTemplateDocument(json.RawMessage(test.document), json.RawMessage(test.request))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(merged, &got); err != nil {
+				t.Fatalf("invalid merged document: %s", err)
+			}
+			if err := json.Unmarshal([]byte(test.want), &want); err != nil {
+				t.Fatalf("invalid test document: %s", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("merged document:\n got: %s\nwant: %s", merged, test.want)
+			}
+		})
+	}
+}
+
+func TestMergeOverTemplateDocumentInvalidJSON(t *testing.T) {
+	if _, err := MergeOverTemplateDocument(json.RawMessage(`not json`), json.RawMessage(`{}`)); err == nil {
+		t.Error("expected an error for an invalid document")
+	}
+	if _, err := MergeOverTemplateDocument(json.RawMessage(`{}`), json.RawMessage(`[]`)); err == nil {
+		t.Error("expected an error for an invalid request")
+	}
+}
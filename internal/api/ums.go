@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Services, roles, their assignments and the permission catalog of the user management service (UMS).
+
+type ServiceRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+type ServiceResponse struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+// The secret key a service logs in with (see LoginAsService).
+type ServiceSecretRequest struct {
+	SecretKey string `json:"secretKey"`
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type PermissionsResponse struct {
+	Data []Permission `json:"data"`
+}
+
+// Values of RoleAssignment.PrincipalType.
+const (
+	PrincipalTypeUser    = "USER"
+	PrincipalTypeService = "SERVICE"
+)
+
+// A role assigned to a user or a service.
+type RoleAssignment struct {
+	PrincipalID   string `json:"principalId"`
+	PrincipalType string `json:"principalType"`
+}
+
+type RoleAssignmentsResponse struct {
+	Data []RoleAssignment `json:"data"`
+}
+
+// Services, under ums/v2/services like the service login (see LoginAsService).
+func (biotSdkImpl biotSdkImpl) servicesURL(id string) string {
+	if id == "" {
+		return fmt.Sprintf("%s/%s/v2/services", biotSdkImpl.baseUrl, umsPrefix)
+	}
+	return fmt.Sprintf("%s/%s/v2/services/%s", biotSdkImpl.baseUrl, umsPrefix, url.PathEscape(id))
+}
+
+func (biotSdkImpl biotSdkImpl) CreateService(ctx context.Context, accessToken string, request ServiceRequest) (ServiceResponse, error) {
+	var response ServiceResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPost, biotSdkImpl.servicesURL(""), request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) GetService(ctx context.Context, accessToken string, id string) (ServiceResponse, error) {
+	var response ServiceResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodGet, biotSdkImpl.servicesURL(id), nil, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) UpdateService(ctx context.Context, accessToken string, id string, request ServiceRequest) (ServiceResponse, error) {
+	var response ServiceResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPut, biotSdkImpl.servicesURL(id), request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) DeleteService(ctx context.Context, accessToken string, id string) error {
+	return biotSdkImpl.entityRequest(ctx, accessToken, http.MethodDelete, biotSdkImpl.servicesURL(id), nil, nil)
+}
+
+// Replaces the secret key of the service, the previous one stops working.
+func (biotSdkImpl biotSdkImpl) SetServiceSecret(ctx context.Context, accessToken string, id string, request ServiceSecretRequest) error {
+	return biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPut, biotSdkImpl.servicesURL(id)+"/secretKey", request, nil)
+}
+
+// Roles and their assignments, under ums/v1/roles.
+func (biotSdkImpl biotSdkImpl) rolesURL(id string) string {
+	if id == "" {
+		return fmt.Sprintf("%s/%s/v1/roles", biotSdkImpl.baseUrl, umsPrefix)
+	}
+	return fmt.Sprintf("%s/%s/v1/roles/%s", biotSdkImpl.baseUrl, umsPrefix, url.PathEscape(id))
+}
+
+func (biotSdkImpl biotSdkImpl) CreateRole(ctx context.Context, accessToken string, request RoleRequest) (RoleResponse, error) {
+	var response RoleResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPost, biotSdkImpl.rolesURL(""), request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) GetRole(ctx context.Context, accessToken string, id string) (RoleResponse, error) {
+	var response RoleResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodGet, biotSdkImpl.rolesURL(id), nil, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) UpdateRole(ctx context.Context, accessToken string, id string, request RoleRequest) (RoleResponse, error) {
+	var response RoleResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPut, biotSdkImpl.rolesURL(id), request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) DeleteRole(ctx context.Context, accessToken string, id string) error {
+	return biotSdkImpl.entityRequest(ctx, accessToken, http.MethodDelete, biotSdkImpl.rolesURL(id), nil, nil)
+}
+
+func (biotSdkImpl biotSdkImpl) GetRoleAssignments(ctx context.Context, accessToken string, roleID string) (RoleAssignmentsResponse, error) {
+	var response RoleAssignmentsResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodGet, biotSdkImpl.rolesURL(roleID)+"/assignments", nil, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) AssignRole(ctx context.Context, accessToken string, roleID string, assignment RoleAssignment) error {
+	return biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPost, biotSdkImpl.rolesURL(roleID)+"/assignments", assignment, nil)
+}
+
+func (biotSdkImpl biotSdkImpl) UnassignRole(ctx context.Context, accessToken string, roleID string, principalID string) error {
+	return biotSdkImpl.entityRequest(ctx, accessToken, http.MethodDelete, biotSdkImpl.rolesURL(roleID)+"/assignments/"+url.PathEscape(principalID), nil, nil)
+}
+
+func (biotSdkImpl biotSdkImpl) GetPermissions(ctx context.Context, accessToken string) (PermissionsResponse, error) {
+	var permissionsUrl = fmt.Sprintf("%s/%s/v1/permissions", biotSdkImpl.baseUrl, umsPrefix)
+
+	var response PermissionsResponse
+	err := biotSdkImpl.entityRequest(ctx, accessToken, http.MethodGet, permissionsUrl, nil, &response)
+	return response, err
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Organization users and caregivers, by entity type ("organization-user" / "caregiver").
+// The entity APIs are at the same paths as their search (see entitySearchPaths).
+var userEntityTypes = map[string]bool{
+	"organization-user": true,
+	"caregiver":         true,
+}
+
+type UserRequest struct {
+	Name  *EntityName `json:"_name,omitempty"`
+	Email string      `json:"_email,omitempty"`
+	// Sent only on create, the template and organization of a user can not be changed.
+	TemplateID        string           `json:"_templateId,omitempty"`
+	OwnerOrganization *EntityReference `json:"_ownerOrganization,omitempty"`
+
+	// The values of the custom attributes of the template, keyed by attribute name.
+	CustomAttributes map[string]interface{} `json:"-"`
+}
+
+type UserResponse struct {
+	ID                string           `json:"_id"`
+	Name              EntityName       `json:"_name"`
+	Email             string           `json:"_email"`
+	Template          *EntityReference `json:"_template"`
+	OwnerOrganization *EntityReference `json:"_ownerOrganization"`
+
+	// The whole user document, for the values of the custom attributes (see UnmarshalJSON).
+	Document map[string]interface{} `json:"-"`
+}
+
+func (r UserRequest) MarshalJSON() ([]byte, error) {
+	type userRequestAlias UserRequest
+	return marshalEntityDocument(userRequestAlias(r), r.CustomAttributes)
+}
+
+func (r *UserResponse) UnmarshalJSON(data []byte) error {
+	type userResponseAlias UserResponse
+
+	var alias userResponseAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &alias.Document); err != nil {
+		return err
+	}
+
+	*r = UserResponse(alias)
+	return nil
+}
+
+func userURL(baseUrl string, entityType string, id string) (string, error) {
+	if !userEntityTypes[entityType] {
+		return "", fmt.Errorf("users of type [%s] are not supported", entityType)
+	}
+
+	if id == "" {
+		return fmt.Sprintf("%s/%s", baseUrl, entitySearchPaths[entityType]), nil
+	}
+	return fmt.Sprintf("%s/%s/%s", baseUrl, entitySearchPaths[entityType], url.PathEscape(id)), nil
+}
+
+// sendInvitation controls whether BioT emails the user an invitation to set a password.
+func (biotSdkImpl biotSdkImpl) CreateUser(ctx context.Context, accessToken string, entityType string, request UserRequest, sendInvitation bool) (UserResponse, error) {
+	userUrl, err := userURL(biotSdkImpl.baseUrl, entityType, "")
+	if err != nil {
+		return UserResponse{}, err
+	}
+	userUrl += "?sendInvitation=" + strconv.FormatBool(sendInvitation)
+
+	var response UserResponse
+	err = biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPost, userUrl, request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) GetUser(ctx context.Context, accessToken string, entityType string, id string) (UserResponse, error) {
+	userUrl, err := userURL(biotSdkImpl.baseUrl, entityType, id)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	var response UserResponse
+	err = biotSdkImpl.entityRequest(ctx, accessToken, http.MethodGet, userUrl, nil, &response)
+	return response, err
+}
+
+// Only the fields that are set in the request are changed (PATCH).
+func (biotSdkImpl biotSdkImpl) UpdateUser(ctx context.Context, accessToken string, entityType string, id string, request UserRequest) (UserResponse, error) {
+	userUrl, err := userURL(biotSdkImpl.baseUrl, entityType, id)
+	if err != nil {
+		return UserResponse{}, err
+	}
+
+	var response UserResponse
+	err = biotSdkImpl.entityRequest(ctx, accessToken, http.MethodPatch, userUrl, request, &response)
+	return response, err
+}
+
+func (biotSdkImpl biotSdkImpl) DeleteUser(ctx context.Context, accessToken string, entityType string, id string) error {
+	userUrl, err := userURL(biotSdkImpl.baseUrl, entityType, id)
+	if err != nil {
+		return err
+	}
+
+	return biotSdkImpl.entityRequest(ctx, accessToken, http.MethodDelete, userUrl, nil, nil)
+}
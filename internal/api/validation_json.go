@@ -80,22 +80,28 @@ func (v Validation) MarshalJSON() ([]byte, error) {
 
 	// Parse the stored string to get the actual value
 	if v.DefaultValue != nil && *v.DefaultValue != "" {
-		// First, try to parse as JSON (for objects and arrays)
-		var defaultValue interface{}
-		if err := json.Unmarshal([]byte(*v.DefaultValue), &defaultValue); err == nil {
-			// Successfully parsed as JSON - use the parsed value
-			alias.DefaultValue = defaultValue
-		} else {
-			// Not valid JSON, try to parse as number
-			if num, err := strconv.ParseFloat(*v.DefaultValue, 64); err == nil {
-				// It's a numeric string, send as number
-				alias.DefaultValue = num
-			} else {
-				// Not a number either, treat as plain string
-				alias.DefaultValue = *v.DefaultValue
-			}
-		}
+		alias.DefaultValue = ParseDefaultValue(*v.DefaultValue)
 	}
 
 	return json.Marshal(alias)
 }
+
+// ParseDefaultValue converts the string representation of a defaultValue
+// to the JSON value that is sent to BioT
+func ParseDefaultValue(defaultValue string) interface{} {
+	// First, try to parse as JSON (for objects and arrays)
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(defaultValue), &parsed); err == nil {
+		// Successfully parsed as JSON - use the parsed value
+		return parsed
+	}
+
+	// Not valid JSON, try to parse as number
+	if num, err := strconv.ParseFloat(defaultValue, 64); err == nil {
+		// It's a numeric string, send as number
+		return num
+	}
+
+	// Not a number either, treat as plain string
+	return defaultValue
+}
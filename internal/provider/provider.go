@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -17,7 +18,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"biot.com/terraform-provider-biot-gen2/internal/api"
+	"biot.com/terraform-provider-biot-gen2/internal/resources/organization"
 	"biot.com/terraform-provider-biot-gen2/internal/resources/template"
+	"biot.com/terraform-provider-biot-gen2/internal/resources/ums"
 	"biot.com/terraform-provider-biot-gen2/internal/version"
 )
 
@@ -139,24 +142,41 @@ func (p *BiotProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	// Example client configuration for data sources and resources
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *BiotProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		template.NewResource,
+		template.NewTemplateSetResource,
+		template.NewTemplateAttributeResource,
+		template.NewTemplateJSONResource,
+		organization.NewOrganizationResource,
+		organization.NewOrganizationUserResource,
+		organization.NewCaregiverResource,
+		ums.NewRoleResource,
+		ums.NewRoleAssignmentResource,
+		ums.NewServiceResource,
 	}
 }
 
 func (p *BiotProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
-		// NewExampleEphemeralResource,
+		ums.NewServiceSecretEphemeralResource,
+	}
+}
+
+func (p *BiotProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		template.NewTemplateListResource,
 	}
 }
 
 func (p *BiotProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// NewExampleDataSource,
 		// TODO: Add datasource for template IDs.
+		ums.NewPermissionsDataSource,
 	}
 }
 
@@ -2,158 +2,182 @@ package biotplanmodifiers
 
 import (
 	"context"
+	"fmt"
 
 	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type CopyIDFromStateByNameSetModifier struct{}
+// Copies the IDs of set elements (objects with "id", "name" and "display_name") from state to plan, so existing
+// elements keep their ID.
+// Elements are matched by name. An element with a new name is matched to a removed element with the same
+// display_name, which is planned as an in-place rename. An element with an explicit (configured) ID is never changed.
+type CopyIDFromStateSetModifier struct{}
 
-func (m CopyIDFromStateByNameSetModifier) Description(_ context.Context) string {
-	return "Copies ID from state to plan based on attribute name."
+func (m CopyIDFromStateSetModifier) Description(_ context.Context) string {
+	return "Copies ID from state to plan based on the element name, or the display name of a renamed element."
 }
 
-func (m CopyIDFromStateByNameSetModifier) MarkdownDescription(ctx context.Context) string {
+func (m CopyIDFromStateSetModifier) MarkdownDescription(ctx context.Context) string {
 	return m.Description(ctx)
 }
 
-func (m CopyIDFromStateByNameSetModifier) PlanModifySet(
+func (m CopyIDFromStateSetModifier) PlanModifySet(
 	ctx context.Context,
 	req planmodifier.SetRequest,
 	resp *planmodifier.SetResponse,
 ) {
-	newSet, diags := copyIDFromStateByNameForSet(ctx, req.PlanValue, req.StateValue)
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+
+	newSet, diags := copyIDFromStateForSet(ctx, req.Path, req.PlanValue, req.StateValue)
 	resp.Diagnostics.Append(diags...)
 	if !diags.HasError() {
 		resp.PlanValue = newSet
 	}
 }
 
-func copyIDFromStateByNameForSet(
+type setElement struct {
+	object      types.Object
+	id          types.String
+	name        string
+	displayName string
+}
+
+func toSetElements(ctx context.Context, set types.Set) ([]setElement, diag.Diagnostics) {
+	var objects []types.Object
+	diags := set.ElementsAs(ctx, &objects, false)
+
+	elements := make([]setElement, 0, len(objects))
+	for _, object := range objects {
+		attributes := object.Attributes()
+		id, _ := attributes["id"].(types.String)
+		name, _ := attributes["name"].(types.String)
+		displayName, _ := attributes["display_name"].(types.String)
+
+		elements = append(elements, setElement{
+			object:      object,
+			id:          id,
+			name:        name.ValueString(),
+			displayName: displayName.ValueString(),
+		})
+	}
+
+	return elements, diags
+}
+
+func hasValue(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
+
+func copyIDFromStateForSet(
 	ctx context.Context,
+	setPath path.Path,
 	planSet types.Set,
 	stateSet types.Set,
 ) (types.Set, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	var planObjs []types.Object
-	diags.Append(planSet.ElementsAs(ctx, &planObjs, false)...)
+	planElements, elementDiags := toSetElements(ctx, planSet)
+	diags.Append(elementDiags...)
+	stateElements, elementDiags := toSetElements(ctx, stateSet)
+	diags.Append(elementDiags...)
 	if diags.HasError() {
 		return planSet, diags
 	}
 
-	// Build name -> attribute (with ID and nested selectable_values)
-	stateAttrMap := map[string]types.Object{}
-	if !stateSet.IsNull() && !stateSet.IsUnknown() {
-		var stateObjs []types.Object
-		diags.Append(stateSet.ElementsAs(ctx, &stateObjs, false)...)
-		if diags.HasError() {
-			return planSet, diags
+	stateByName := map[string]setElement{}
+	stateByID := map[string]setElement{}
+	for _, element := range stateElements {
+		stateByName[element.name] = element
+		if hasValue(element.id) {
+			stateByID[element.id.ValueString()] = element
 		}
+	}
 
-		for _, obj := range stateObjs {
-			attrMap := obj.Attributes()
-			name, ok := attrMap["name"].(types.String)
-			if ok && !name.IsNull() && !name.IsUnknown() {
-				stateAttrMap[name.ValueString()] = obj
+	planNames := map[string]bool{}
+	claimedIDs := map[string]bool{}
+	for _, element := range planElements {
+		planNames[element.name] = true
+		if hasValue(element.id) {
+			claimedIDs[element.id.ValueString()] = true
+			if previous, ok := stateByID[element.id.ValueString()]; ok && previous.name != element.name {
+				diags.AddAttributeWarning(setPath, "Selectable value will be renamed", renameDetail(previous, element))
 			}
 		}
 	}
 
-	updatedObjs := make([]attr.Value, 0, len(planObjs))
-
-	for _, planObj := range planObjs {
-		attrMap := planObj.Attributes()
-		nameVal, _ := attrMap["name"].(types.String)
-		idVal, _ := attrMap["id"].(types.String)
-
-		// Copy to new map
-		newAttrMap := make(map[string]attr.Value, len(attrMap))
-		maps.Copy(newAttrMap, attrMap)
+	// 1. Same name
+	for i, element := range planElements {
+		if hasValue(element.id) {
+			continue
+		}
+		if previous, ok := stateByName[element.name]; ok && hasValue(previous.id) && !claimedIDs[previous.id.ValueString()] {
+			planElements[i].id = previous.id
+			claimedIDs[previous.id.ValueString()] = true
+		}
+	}
 
-		attrName := nameVal.ValueString()
-		stateObj, found := stateAttrMap[attrName]
-		if found {
-			stateAttrMap := stateObj.Attributes()
+	// 2. Renamed: a removed element with the same display name
+	for i, element := range planElements {
+		if hasValue(element.id) || element.displayName == "" {
+			continue
+		}
 
-			// 1. Copy attribute ID
-			if idVal.IsNull() || idVal.IsUnknown() {
-				if stateID, ok := stateAttrMap["id"].(types.String); ok && !stateID.IsNull() && !stateID.IsUnknown() {
-					newAttrMap["id"] = stateID
-				}
+		var candidates []setElement
+		for _, previous := range stateElements {
+			if planNames[previous.name] || !hasValue(previous.id) || claimedIDs[previous.id.ValueString()] {
+				continue
 			}
-
-			// 2. Copy selectable_values IDs
-			planSelectable, planHas := attrMap["selectable_values"].(types.Set)
-			stateSelectable, stateHas := stateAttrMap["selectable_values"].(types.Set)
-
-			if planHas && stateHas && !planSelectable.IsNull() && !planSelectable.IsUnknown() {
-				var planVals []types.Object
-				diags.Append(planSelectable.ElementsAs(ctx, &planVals, false)...)
-
-				var stateVals []types.Object
-				diags.Append(stateSelectable.ElementsAs(ctx, &stateVals, false)...)
-
-				// Build name -> id map from state selectable values
-				stateValueIDMap := map[string]string{}
-				for _, sv := range stateVals {
-					svAttrs := sv.Attributes()
-					name, _ := svAttrs["name"].(types.String)
-					id, _ := svAttrs["id"].(types.String)
-					if !name.IsNull() && !name.IsUnknown() && !id.IsNull() && !id.IsUnknown() {
-						stateValueIDMap[name.ValueString()] = id.ValueString()
-					}
-				}
-
-				// Rebuild plan selectable values with copied IDs
-				var updatedValues []attr.Value
-				for _, sv := range planVals {
-					svAttrs := sv.Attributes()
-					nameVal, _ := svAttrs["name"].(types.String)
-					idVal, _ := svAttrs["id"].(types.String)
-
-					newSvAttrs := make(map[string]attr.Value, len(svAttrs))
-					maps.Copy(newSvAttrs, svAttrs)
-
-					if (idVal.IsNull() || idVal.IsUnknown()) && !nameVal.IsNull() && !nameVal.IsUnknown() {
-						if id, ok := stateValueIDMap[nameVal.ValueString()]; ok {
-							newSvAttrs["id"] = types.StringValue(id)
-						}
-					}
-
-					svType := sv.Type(ctx).(types.ObjectType)
-					newSvObj, svDiags := types.ObjectValue(svType.AttributeTypes(), newSvAttrs)
-					diags.Append(svDiags...)
-					if svDiags.HasError() {
-						return planSet, diags
-					}
-					updatedValues = append(updatedValues, newSvObj)
-				}
-
-				// Set updated selectable_values
-				newSelectableSet, setDiags := types.SetValue(planSelectable.ElementType(ctx), updatedValues)
-				diags.Append(setDiags...)
-				if !setDiags.HasError() {
-					newAttrMap["selectable_values"] = newSelectableSet
-				}
+			if previous.displayName == element.displayName {
+				candidates = append(candidates, previous)
 			}
 		}
 
-		objType := planObj.Type(ctx).(types.ObjectType)
-		newObj, objDiags := types.ObjectValue(objType.AttributeTypes(), newAttrMap)
-		diags.Append(objDiags...)
-		if objDiags.HasError() {
+		// More than one removed element with the same display name, the rename is ambiguous.
+		if len(candidates) != 1 {
+			continue
+		}
+
+		planElements[i].id = candidates[0].id
+		claimedIDs[candidates[0].id.ValueString()] = true
+		diags.AddAttributeWarning(
+			setPath,
+			"Selectable value will be renamed",
+			renameDetail(candidates[0], element)+" It was matched by display_name, if this is a new value and not a rename, give it a different display_name.",
+		)
+	}
+
+	updatedObjects := make([]attr.Value, 0, len(planElements))
+	for _, element := range planElements {
+		newAttributes := make(map[string]attr.Value, len(element.object.Attributes()))
+		maps.Copy(newAttributes, element.object.Attributes())
+		newAttributes["id"] = element.id
+
+		objectType := element.object.Type(ctx).(types.ObjectType)
+		newObject, objectDiags := types.ObjectValue(objectType.AttributeTypes(), newAttributes)
+		diags.Append(objectDiags...)
+		if objectDiags.HasError() {
 			return planSet, diags
 		}
 
-		updatedObjs = append(updatedObjs, newObj)
+		updatedObjects = append(updatedObjects, newObject)
 	}
 
-	newSet, setDiags := types.SetValue(planSet.ElementType(ctx), updatedObjs)
+	newSet, setDiags := types.SetValue(planSet.ElementType(ctx), updatedObjects)
 	diags.Append(setDiags...)
 	return newSet, diags
 }
+
+func renameDetail(previous setElement, element setElement) string {
+	return fmt.Sprintf(
+		"Selectable value [%s] will be renamed to [%s] in place, it keeps its ID [%s] so existing entity values are not orphaned.",
+		previous.name, element.name, previous.id.ValueString(),
+	)
+}
@@ -0,0 +1,144 @@
+package biotplanmodifiers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var testSelectableValueType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":           types.StringType,
+	"name":         types.StringType,
+	"display_name": types.StringType,
+}}
+
+type testSelectableValue struct {
+	id          types.String
+	name        string
+	displayName string
+}
+
+func testSelectableValues(t *testing.T, values ...testSelectableValue) types.Set {
+	t.Helper()
+
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.ObjectValueMust(testSelectableValueType.AttrTypes, map[string]attr.Value{
+			"id":           value.id,
+			"name":         types.StringValue(value.name),
+			"display_name": types.StringValue(value.displayName),
+		}))
+	}
+	return types.SetValueMust(testSelectableValueType, elements)
+}
+
+// Returns the planned IDs keyed by name, "" for unknown IDs.
+func plannedIDs(t *testing.T, set types.Set) map[string]string {
+	t.Helper()
+
+	ids := map[string]string{}
+	for _, element := range set.Elements() {
+		attributes := element.(types.Object).Attributes()
+		ids[attributes["name"].(types.String).ValueString()] = attributes["id"].(types.String).ValueString()
+	}
+	return ids
+}
+
+func TestCopyIDFromStateSetModifier(t *testing.T) {
+	unknown := types.StringUnknown()
+
+	tests := []struct {
+		name         string
+		state        []testSelectableValue
+		plan         []testSelectableValue
+		wantIDs      map[string]string
+		wantWarnings int
+	}{
+		{
+			name:    "same name keeps the ID",
+			state:   []testSelectableValue{{types.StringValue("1"), "red", "Red"}, {types.StringValue("2"), "blue", "Blue"}},
+			plan:    []testSelectableValue{{unknown, "red", "Red"}, {unknown, "blue", "Blue"}},
+			wantIDs: map[string]string{"red": "1", "blue": "2"},
+		},
+		{
+			name:    "new value stays unknown",
+			state:   []testSelectableValue{{types.StringValue("1"), "red", "Red"}},
+			plan:    []testSelectableValue{{unknown, "red", "Red"}, {unknown, "green", "Green"}},
+			wantIDs: map[string]string{"red": "1", "green": ""},
+		},
+		{
+			name:         "renamed value is matched by display name",
+			state:        []testSelectableValue{{types.StringValue("1"), "red", "Red"}},
+			plan:         []testSelectableValue{{unknown, "dark_red", "Red"}},
+			wantIDs:      map[string]string{"dark_red": "1"},
+			wantWarnings: 1,
+		},
+		{
+			name:    "ambiguous rename is not matched",
+			state:   []testSelectableValue{{types.StringValue("1"), "red", "Red"}, {types.StringValue("2"), "crimson", "Red"}},
+			plan:    []testSelectableValue{{unknown, "dark_red", "Red"}},
+			wantIDs: map[string]string{"dark_red": ""},
+		},
+		{
+			name:    "display name of a value that is kept is not matched",
+			state:   []testSelectableValue{{types.StringValue("1"), "red", "Red"}},
+			plan:    []testSelectableValue{{unknown, "red", "Red"}, {unknown, "dark_red", "Red"}},
+			wantIDs: map[string]string{"red": "1", "dark_red": ""},
+		},
+		{
+			name:         "configured ID is kept and its rename is reported",
+			state:        []testSelectableValue{{types.StringValue("1"), "red", "Red"}, {types.StringValue("2"), "blue", "Blue"}},
+			plan:         []testSelectableValue{{types.StringValue("1"), "scarlet", "Scarlet"}, {unknown, "red", "Red"}},
+			wantIDs:      map[string]string{"scarlet": "1", "red": ""},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := planmodifier.SetRequest{
+				Path:       path.Root("selectable_values"),
+				PlanValue:  testSelectableValues(t, test.plan...),
+				StateValue: testSelectableValues(t, test.state...),
+			}
+			resp := &planmodifier.SetResponse{PlanValue: req.PlanValue}
+
+			CopyIDFromStateSetModifier{}.PlanModifySet(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics.Errors())
+			}
+
+			got := plannedIDs(t, resp.PlanValue)
+			if len(got) != len(test.wantIDs) {
+				t.Fatalf("planned IDs: got %v, want %v", got, test.wantIDs)
+			}
+			for name, want := range test.wantIDs {
+				if got[name] != want {
+					t.Errorf("planned ID of [%s]: got %q, want %q", name, got[name], want)
+				}
+			}
+			if warnings := resp.Diagnostics.WarningsCount(); warnings != test.wantWarnings {
+				t.Errorf("got %d warnings, want %d: %v", warnings, test.wantWarnings, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
+
+func TestCopyIDFromStateSetModifierWithoutState(t *testing.T) {
+	plan := testSelectableValues(t, testSelectableValue{types.StringUnknown(), "red", "Red"})
+	req := planmodifier.SetRequest{
+		Path:       path.Root("selectable_values"),
+		PlanValue:  plan,
+		StateValue: types.SetNull(testSelectableValueType),
+	}
+	resp := &planmodifier.SetResponse{PlanValue: plan}
+
+	CopyIDFromStateSetModifier{}.PlanModifySet(context.Background(), req, resp)
+	if !resp.PlanValue.Equal(plan) {
+		t.Errorf("plan changed on create: %s", resp.PlanValue)
+	}
+}
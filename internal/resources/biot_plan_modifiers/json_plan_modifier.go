@@ -26,23 +26,28 @@ func (m JsonNormalizePlanModifier) PlanModifyString(ctx context.Context, req pla
 
 	original := req.PlanValue.ValueString()
 
-	var parsed interface{}
-	err := json.Unmarshal([]byte(original), &parsed)
+	normalized, err := NormalizeJSON(original)
 	if err != nil {
 		// Not valid JSON, leave as is
 		return
 	}
 
-	normalizedBytes, err := json.Marshal(parsed)
-	if err != nil {
-		// Failed to marshal, leave as is
-		return
-	}
-
-	normalized := string(normalizedBytes)
-
 	// If normalized JSON differs, update plan value
 	if normalized != original {
 		resp.PlanValue = types.StringValue(normalized)
 	}
 }
+
+// NormalizeJSON returns the compact form of a JSON value, with object keys sorted.
+func NormalizeJSON(value string) (string, error) {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(parsed)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
@@ -0,0 +1,36 @@
+package biotplanmodifiers
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// Works like stringplanmodifier.RequiresReplace, but also adds a plan warning explaining the consequences
+// of the replacement (e.g. data that is deleted together with the resource).
+type RequiresReplaceWithWarningModifier struct {
+	WarningSummary string
+	WarningDetail  string
+}
+
+func (m RequiresReplaceWithWarningModifier) Description(_ context.Context) string {
+	return "If the value of this attribute changes, Terraform will destroy and recreate the resource."
+}
+
+func (m RequiresReplaceWithWarningModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m RequiresReplaceWithWarningModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Resource is being created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	resp.RequiresReplace = true
+	resp.Diagnostics.AddAttributeWarning(req.Path, m.WarningSummary, m.WarningDetail)
+}
@@ -0,0 +1,92 @@
+package biotplanmodifiers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// Works like UseStateForUnknown for a computed value that is derived from sibling attributes (e.g. an ID resolved
+// from a name): the state value (null included) is kept only while the configured siblings are unchanged, otherwise
+// the value stays unknown so it is computed again on apply.
+type UseStateWhenSiblingsUnchangedModifier struct {
+	Siblings []string
+	// Optional + Computed siblings, compared only when they are set in the configuration.
+	ComputedSiblings []string
+}
+
+func (m UseStateWhenSiblingsUnchangedModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("Keeps the value from state while %s are unchanged.", strings.Join(m.Siblings, ", "))
+}
+
+func (m UseStateWhenSiblingsUnchangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m UseStateWhenSiblingsUnchangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.PlanValue.IsUnknown() || !req.ConfigValue.IsNull() {
+		return
+	}
+
+	useState, diags := m.siblingsUnchanged(ctx, req.Path, req.Config, req.State)
+	resp.Diagnostics.Append(diags...)
+	if useState {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m UseStateWhenSiblingsUnchangedModifier) PlanModifyList(ctx context.Context, req planmodifier.ListRequest, resp *planmodifier.ListResponse) {
+	if !req.PlanValue.IsUnknown() || !req.ConfigValue.IsNull() {
+		return
+	}
+
+	useState, diags := m.siblingsUnchanged(ctx, req.Path, req.Config, req.State)
+	resp.Diagnostics.Append(diags...)
+	if useState {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+func (m UseStateWhenSiblingsUnchangedModifier) siblingsUnchanged(ctx context.Context, attributePath path.Path, config tfsdk.Config, state tfsdk.State) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Resource is being created
+	if state.Raw.IsNull() {
+		return false, diags
+	}
+
+	// The parent object is new, there is no state to keep.
+	var stateParent attr.Value
+	diags.Append(state.GetAttribute(ctx, attributePath.ParentPath(), &stateParent)...)
+	if diags.HasError() || stateParent == nil || stateParent.IsNull() {
+		return false, diags
+	}
+
+	for _, sibling := range append(append([]string{}, m.Siblings...), m.ComputedSiblings...) {
+		siblingPath := attributePath.ParentPath().AtName(sibling)
+
+		var configValue, stateValue attr.Value
+		diags.Append(config.GetAttribute(ctx, siblingPath, &configValue)...)
+		diags.Append(state.GetAttribute(ctx, siblingPath, &stateValue)...)
+		if diags.HasError() || configValue == nil || stateValue == nil {
+			return false, diags
+		}
+
+		if configValue.IsNull() && slices.Contains(m.ComputedSiblings, sibling) {
+			continue
+		}
+
+		if configValue.IsUnknown() || !configValue.Equal(stateValue) {
+			return false, diags
+		}
+	}
+
+	return true, diags
+}
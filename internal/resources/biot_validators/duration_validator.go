@@ -0,0 +1,35 @@
+package biotvalidators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Validates that a string is a positive Go duration, e.g. "30s", "10m" or "1h30m".
+type DurationValidator struct{}
+
+func Duration() DurationValidator {
+	return DurationValidator{}
+}
+
+func (v DurationValidator) Description(ctx context.Context) string {
+	return "Value must be a positive duration, e.g. 30s, 10m or 1h30m"
+}
+
+func (v DurationValidator) MarkdownDescription(ctx context.Context) string {
+	return "Value must be a positive duration, e.g. `30s`, `10m` or `1h30m`"
+}
+
+func (v DurationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("Value [%s] is not a positive duration, use e.g. 30s, 10m or 1h30m.", req.ConfigValue.ValueString()))
+	}
+}
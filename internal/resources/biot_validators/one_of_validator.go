@@ -0,0 +1,129 @@
+package biotvalidators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Validates that a string is one of a fixed list of values.
+// Unlike a plain "one of" check, the error suggests the closest known value to catch typos.
+// With WarnOnly, an unknown value is reported as a warning, for lists that BioT may extend (e.g. attribute types).
+type OneOfWithSuggestionValidator struct {
+	Values   []string
+	WarnOnly bool
+}
+
+func OneOfWithSuggestion(values []string) OneOfWithSuggestionValidator {
+	return OneOfWithSuggestionValidator{Values: values}
+}
+
+// Like OneOfWithSuggestion, but values that are not in the list are accepted with a warning.
+func KnownValuesWithSuggestion(values []string) OneOfWithSuggestionValidator {
+	return OneOfWithSuggestionValidator{Values: values, WarnOnly: true}
+}
+
+func (v OneOfWithSuggestionValidator) Description(ctx context.Context) string {
+	if v.WarnOnly {
+		return fmt.Sprintf("Known values: %s", strings.Join(v.Values, ", "))
+	}
+	return fmt.Sprintf("Value must be one of: %s", strings.Join(v.Values, ", "))
+}
+
+func (v OneOfWithSuggestionValidator) MarkdownDescription(ctx context.Context) string {
+	if v.WarnOnly {
+		return fmt.Sprintf("Known values: %s", FormatMarkdownValues(v.Values))
+	}
+	return fmt.Sprintf("Value must be one of: %s", FormatMarkdownValues(v.Values))
+}
+
+func (v OneOfWithSuggestionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	for _, known := range v.Values {
+		if value == known {
+			return
+		}
+	}
+
+	if v.WarnOnly {
+		detail := fmt.Sprintf("Value [%s] is not known to the provider, it is sent to BioT as is. Known values are: %s.", value, strings.Join(v.Values, ", "))
+		if suggestion, ok := closestValue(value, v.Values); ok {
+			detail = fmt.Sprintf("Value [%s] is not known to the provider, did you mean [%s]? It is sent to BioT as is. Known values are: %s.", value, suggestion, strings.Join(v.Values, ", "))
+		}
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Unknown value", detail)
+		return
+	}
+
+	detail := fmt.Sprintf("Value [%s] is not supported. Supported values are: %s.", value, strings.Join(v.Values, ", "))
+	if suggestion, ok := closestValue(value, v.Values); ok {
+		detail = fmt.Sprintf("Value [%s] is not supported, did you mean [%s]? Supported values are: %s.", value, suggestion, strings.Join(v.Values, ", "))
+	}
+
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid value", detail)
+}
+
+// Formats values as a markdown list of inline code, used in schema MarkdownDescription.
+func FormatMarkdownValues(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("`%s`", value))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Returns the known value closest to the given value, when it is close enough to be a typo.
+func closestValue(value string, values []string) (string, bool) {
+	normalized := normalizeForComparison(value)
+
+	best := ""
+	bestDistance := -1
+	for _, known := range values {
+		distance := levenshteinDistance(normalized, normalizeForComparison(known))
+		if bestDistance == -1 || distance < bestDistance {
+			best = known
+			bestDistance = distance
+		}
+	}
+
+	// Allow roughly one typo for every three characters.
+	maxDistance := max(2, len(normalized)/3)
+	if bestDistance == -1 || bestDistance > maxDistance {
+		return "", false
+	}
+
+	return best, true
+}
+
+// Case and separator differences ("date-time" vs "DATE_TIME") are always considered typos.
+func normalizeForComparison(value string) string {
+	return strings.NewReplacer("-", "_", " ", "_").Replace(strings.ToUpper(strings.TrimSpace(value)))
+}
+
+func levenshteinDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitutionCost := 1
+			if a[i-1] == b[j-1] {
+				substitutionCost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+substitutionCost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,57 @@
+package biotvalidators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOneOfWithSuggestionValidator(t *testing.T) {
+	values := []string{"DECIMAL", "INTEGER"}
+
+	tests := []struct {
+		name         string
+		validator    OneOfWithSuggestionValidator
+		value        types.String
+		wantErrors   int
+		wantWarnings int
+	}{
+		{name: "known value", validator: OneOfWithSuggestion(values), value: types.StringValue("INTEGER")},
+		{name: "unknown value", validator: OneOfWithSuggestion(values), value: types.StringValue("LONG"), wantErrors: 1},
+		{name: "null value", validator: OneOfWithSuggestion(values), value: types.StringNull()},
+		{name: "known value, warn only", validator: KnownValuesWithSuggestion(values), value: types.StringValue("DECIMAL")},
+		{name: "unknown value, warn only", validator: KnownValuesWithSuggestion(values), value: types.StringValue("LONG"), wantWarnings: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("sub_type"), ConfigValue: test.value}
+			resp := &validator.StringResponse{}
+
+			test.validator.ValidateString(context.Background(), req, resp)
+			if errors := resp.Diagnostics.ErrorsCount(); errors != test.wantErrors {
+				t.Errorf("got %d errors, want %d: %v", errors, test.wantErrors, resp.Diagnostics.Errors())
+			}
+			if warnings := resp.Diagnostics.WarningsCount(); warnings != test.wantWarnings {
+				t.Errorf("got %d warnings, want %d: %v", warnings, test.wantWarnings, resp.Diagnostics.Warnings())
+			}
+		})
+	}
+}
+
+func TestClosestValue(t *testing.T) {
+	values := []string{"DATE_TIME", "DECIMAL", "INTEGER"}
+
+	if got, ok := closestValue("date-time", values); !ok || got != "DATE_TIME" {
+		t.Errorf("closest value of [date-time]: got %q, %t", got, ok)
+	}
+	if got, ok := closestValue("INTEGR", values); !ok || got != "INTEGER" {
+		t.Errorf("closest value of [INTEGR]: got %q, %t", got, ok)
+	}
+	if got, ok := closestValue("WAVEFORM", values); ok {
+		t.Errorf("closest value of [WAVEFORM]: got %q, want none", got)
+	}
+}
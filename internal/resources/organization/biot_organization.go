@@ -0,0 +1,298 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+func NewOrganizationResource() resource.Resource {
+	return &BiotOrganizationResource{}
+}
+
+type BiotOrganizationResource struct {
+	client *api.APIClient
+}
+
+type TerraformOrganization struct {
+	ID                     types.String                   `tfsdk:"id"`
+	Name                   types.String                   `tfsdk:"name"`
+	Description            types.String                   `tfsdk:"description"`
+	TemplateName           types.String                   `tfsdk:"template_name"`
+	TemplateID             types.String                   `tfsdk:"template_id"`
+	PrimaryAdministrator   *TerraformPrimaryAdministrator `tfsdk:"primary_administrator"`
+	PrimaryAdministratorID types.String                   `tfsdk:"primary_administrator_id"`
+	CustomAttributes       map[string]types.String        `tfsdk:"custom_attributes"`
+}
+
+type TerraformPrimaryAdministrator struct {
+	FirstName    types.String `tfsdk:"first_name"`
+	LastName     types.String `tfsdk:"last_name"`
+	Email        types.String `tfsdk:"email"`
+	TemplateName types.String `tfsdk:"template_name"`
+}
+
+var _ resource.ResourceWithImportState = &BiotOrganizationResource{}
+var _ resource.ResourceWithModifyPlan = &BiotOrganizationResource{}
+
+func (r *BiotOrganizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_organization"
+}
+
+func (r *BiotOrganizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotOrganizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An organization, created with its primary administrator.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the organization.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"template_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the organization template. The template of an organization can not be changed, changing it replaces the organization.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"template_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the organization template, resolved from template_name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"primary_administrator": schema.SingleNestedAttribute{
+				Required: true,
+				MarkdownDescription: "The primary administrator, created with the organization. Used only on create: changes are not applied " +
+					"to the existing administrator, manage it with `biot_organization_user` (import it by email).",
+				Attributes: map[string]schema.Attribute{
+					"first_name": schema.StringAttribute{Required: true},
+					"last_name":  schema.StringAttribute{Required: true},
+					"email":      schema.StringAttribute{Required: true},
+					"template_name": schema.StringAttribute{
+						Optional:    true,
+						Description: "The name of the organization user template of the administrator. When not set, BioT uses the default template.",
+					},
+				},
+			},
+			"primary_administrator_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the primary administrator (an organization user).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"custom_attributes": customAttributesSchema("organization"),
+		},
+	}
+}
+
+func (r *BiotOrganizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed, or the provider is not configured yet (e.g. during validate).
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(checkPlannedCustomAttributeValues(ctx, r.client, "organization", req)...)
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state TerraformOrganization
+	if req.Plan.Get(ctx, &plan).HasError() || req.State.Get(ctx, &state).HasError() {
+		return
+	}
+
+	if state.PrimaryAdministrator != nil && plan.PrimaryAdministrator != nil && *state.PrimaryAdministrator != *plan.PrimaryAdministrator {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("primary_administrator"),
+			"Primary administrator is not updated",
+			fmt.Sprintf("primary_administrator is used only when organization [%s] is created, the change is saved but not applied to the existing administrator.", state.Name.ValueString()),
+		)
+	}
+}
+
+func (r *BiotOrganizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformOrganization
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetOrganization(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			// The organization does not exist in the backend, removing it from local state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read organization: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapOrganizationResponse(response, state))...)
+}
+
+func (r *BiotOrganizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformOrganization
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.GetTemplateByTypeAndName(ctx, "organization", plan.TemplateName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("template_name"), "Template not found", fmt.Sprintf("Failed to find organization template [%s]: %s", plan.TemplateName.ValueString(), err))
+		return
+	}
+
+	request, diags := organizationRequest(plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	request.TemplateID = template.ID
+
+	request.PrimaryAdministrator = &api.PrimaryAdministratorRequest{
+		Name: api.EntityName{
+			FirstName: plan.PrimaryAdministrator.FirstName.ValueString(),
+			LastName:  plan.PrimaryAdministrator.LastName.ValueString(),
+		},
+		Email: plan.PrimaryAdministrator.Email.ValueString(),
+	}
+	if templateName := plan.PrimaryAdministrator.TemplateName; !templateName.IsNull() {
+		administratorTemplate, err := r.client.GetTemplateByTypeAndName(ctx, "organization-user", templateName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("primary_administrator").AtName("template_name"), "Template not found", fmt.Sprintf("Failed to find organization user template [%s]: %s", templateName.ValueString(), err))
+			return
+		}
+		request.PrimaryAdministrator.TemplateID = administratorTemplate.ID
+	}
+
+	response, err := r.client.CreateOrganization(ctx, request)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to create organization: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapOrganizationResponse(response, plan))...)
+}
+
+func (r *BiotOrganizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TerraformOrganization
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := organizationRequest(plan, state.CustomAttributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.UpdateOrganization(ctx, state.ID.ValueString(), request)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to update organization: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapOrganizationResponse(response, plan))...)
+}
+
+func (r *BiotOrganizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformOrganization
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteOrganization(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to delete organization: %s", err))
+	}
+}
+
+// Import state works with an organization ID or an organization name.
+func (r *BiotOrganizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if !uuidPattern.MatchString(id) {
+		var err error
+		id, err = r.client.FindEntityID(ctx, "organization", map[string]interface{}{
+			"_name": map[string]interface{}{"in": []string{req.ID}},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to import organization [%s]", req.ID), fmt.Sprintf("%s\n\nExpected an organization ID or name.", err))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func organizationRequest(plan TerraformOrganization, stateCustomAttributes map[string]types.String) (api.OrganizationRequest, diag.Diagnostics) {
+	customAttributes, diags := customAttributeValues(stateCustomAttributes, plan.CustomAttributes)
+
+	return api.OrganizationRequest{
+		Name:             plan.Name.ValueString(),
+		Description:      plan.Description.ValueStringPointer(),
+		CustomAttributes: customAttributes,
+	}, diags
+}
+
+// Maps the organization response over source (the plan or the prior state), which keeps the configuration-only fields.
+func mapOrganizationResponse(response api.OrganizationResponse, source TerraformOrganization) TerraformOrganization {
+	organization := source
+	organization.ID = types.StringValue(response.ID)
+	organization.Name = types.StringValue(response.Name)
+	organization.Description = utils.StringOrNullPtr(response.Description)
+	organization.CustomAttributes = readCustomAttributeValues(source.CustomAttributes, response.Document)
+
+	organization.TemplateID = types.StringNull()
+	if response.Template != nil {
+		organization.TemplateID = types.StringValue(response.Template.ID)
+		organization.TemplateName = types.StringValue(response.Template.Name)
+	}
+
+	organization.PrimaryAdministratorID = types.StringNull()
+	if response.PrimaryAdministrator != nil {
+		organization.PrimaryAdministratorID = types.StringValue(response.PrimaryAdministrator.ID)
+	}
+
+	return organization
+}
@@ -0,0 +1,278 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+func NewOrganizationUserResource() resource.Resource {
+	return &BiotUserResource{entityType: "organization-user", typeName: "biot_organization_user", description: "organization user"}
+}
+
+func NewCaregiverResource() resource.Resource {
+	return &BiotUserResource{entityType: "caregiver", typeName: "biot_caregiver", description: "caregiver"}
+}
+
+// Organization users and caregivers are both users created from a template, managed the same way.
+type BiotUserResource struct {
+	client *api.APIClient
+
+	entityType  string
+	typeName    string
+	description string
+}
+
+type TerraformUser struct {
+	ID               types.String            `tfsdk:"id"`
+	FirstName        types.String            `tfsdk:"first_name"`
+	LastName         types.String            `tfsdk:"last_name"`
+	Email            types.String            `tfsdk:"email"`
+	TemplateName     types.String            `tfsdk:"template_name"`
+	TemplateID       types.String            `tfsdk:"template_id"`
+	OrganizationID   types.String            `tfsdk:"organization_id"`
+	SendInvitation   types.Bool              `tfsdk:"send_invitation"`
+	CustomAttributes map[string]types.String `tfsdk:"custom_attributes"`
+}
+
+var _ resource.ResourceWithImportState = &BiotUserResource{}
+var _ resource.ResourceWithModifyPlan = &BiotUserResource{}
+
+func (r *BiotUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.typeName
+}
+
+func (r *BiotUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("A %s, created from a %s template. The custom attribute values are checked against the template at plan time.", r.description, r.entityType),
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: fmt.Sprintf("The ID of the %s.", r.description),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"first_name": schema.StringAttribute{
+				Required: true,
+			},
+			"last_name": schema.StringAttribute{
+				Required: true,
+			},
+			"email": schema.StringAttribute{
+				Required: true,
+			},
+			"template_name": schema.StringAttribute{
+				Required:    true,
+				Description: fmt.Sprintf("The name of the %s template. Changing it replaces the %s.", r.entityType, r.description),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"template_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the template, resolved from template_name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: fmt.Sprintf("The ID of the organization of the %s. When not set, the organization of the provider service. Changing it replaces the %s.", r.description, r.description),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"send_invitation": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: fmt.Sprintf("Whether BioT emails the %s an invitation to set a password. Used only on create.", r.description),
+			},
+			"custom_attributes": customAttributesSchema(r.entityType),
+		},
+	}
+}
+
+func (r *BiotUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed, or the provider is not configured yet (e.g. during validate).
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(checkPlannedCustomAttributeValues(ctx, r.client, r.entityType, req)...)
+}
+
+func (r *BiotUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformUser
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetUser(ctx, r.entityType, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			// The user does not exist in the backend, removing it from local state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read %s: %s", r.description, err))
+		return
+	}
+
+	// Not set after import.
+	if state.SendInvitation.IsNull() {
+		state.SendInvitation = types.BoolValue(true)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapUserResponse(response, state))...)
+}
+
+func (r *BiotUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformUser
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userTemplate, err := r.client.GetTemplateByTypeAndName(ctx, r.entityType, plan.TemplateName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("template_name"), "Template not found", fmt.Sprintf("Failed to find %s template [%s]: %s", r.entityType, plan.TemplateName.ValueString(), err))
+		return
+	}
+
+	request, diags := userRequest(plan, nil)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	request.TemplateID = userTemplate.ID
+	if !plan.OrganizationID.IsNull() && !plan.OrganizationID.IsUnknown() {
+		request.OwnerOrganization = &api.EntityReference{ID: plan.OrganizationID.ValueString()}
+	}
+
+	response, err := r.client.CreateUser(ctx, r.entityType, request, plan.SendInvitation.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to create %s: %s", r.description, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapUserResponse(response, plan))...)
+}
+
+func (r *BiotUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TerraformUser
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, diags := userRequest(plan, state.CustomAttributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.UpdateUser(ctx, r.entityType, state.ID.ValueString(), request)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to update %s: %s", r.description, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapUserResponse(response, plan))...)
+}
+
+func (r *BiotUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformUser
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteUser(ctx, r.entityType, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to delete %s: %s", r.description, err))
+	}
+}
+
+// Import state works with a user ID or email.
+func (r *BiotUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if !uuidPattern.MatchString(id) {
+		var err error
+		id, err = r.client.FindEntityID(ctx, r.entityType, map[string]interface{}{
+			"_email": map[string]interface{}{"in": []string{req.ID}},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Failed to import %s [%s]", r.description, req.ID), fmt.Sprintf("%s\n\nExpected a %s ID or email.", err, r.description))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+func userRequest(plan TerraformUser, stateCustomAttributes map[string]types.String) (api.UserRequest, diag.Diagnostics) {
+	customAttributes, diags := customAttributeValues(stateCustomAttributes, plan.CustomAttributes)
+
+	return api.UserRequest{
+		Name: &api.EntityName{
+			FirstName: plan.FirstName.ValueString(),
+			LastName:  plan.LastName.ValueString(),
+		},
+		Email:            plan.Email.ValueString(),
+		CustomAttributes: customAttributes,
+	}, diags
+}
+
+// Maps the user response over source (the plan or the prior state), which keeps the configuration-only fields.
+func mapUserResponse(response api.UserResponse, source TerraformUser) TerraformUser {
+	user := source
+	user.ID = types.StringValue(response.ID)
+	user.FirstName = types.StringValue(response.Name.FirstName)
+	user.LastName = types.StringValue(response.Name.LastName)
+	user.Email = types.StringValue(response.Email)
+	user.CustomAttributes = readCustomAttributeValues(source.CustomAttributes, response.Document)
+
+	user.TemplateID = types.StringNull()
+	if response.Template != nil {
+		user.TemplateID = types.StringValue(response.Template.ID)
+		user.TemplateName = types.StringValue(response.Template.Name)
+	}
+
+	user.OrganizationID = types.StringNull()
+	if response.OwnerOrganization != nil {
+		user.OrganizationID = types.StringValue(response.OwnerOrganization.ID)
+	}
+
+	return user
+}
@@ -0,0 +1,80 @@
+package organization
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	biotplanmodifiers "biot.com/terraform-provider-biot-gen2/internal/resources/biot_plan_modifiers"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// The values of the custom attributes of an entity are JSON strings keyed by attribute name (as value_json of
+// template attributes), e.g. { tier = jsonencode("gold"), beds = jsonencode(12) }.
+func customAttributesSchema(entityType string) schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:    true,
+		ElementType: types.StringType,
+		MarkdownDescription: fmt.Sprintf("The values of the custom attributes of the %s template, keyed by attribute name. "+
+			"Each value is a JSON string, e.g. `jsonencode(\"gold\")`. Attributes that are not listed are not managed; "+
+			"an attribute removed from the map is cleared.", entityType),
+	}
+}
+
+// Decodes the configured values, a removed attribute (in state but not in the plan) is sent as null to clear it.
+func customAttributeValues(state map[string]types.String, plan map[string]types.String) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := make(map[string]interface{}, len(plan))
+
+	for name := range state {
+		if _, configured := plan[name]; !configured {
+			values[name] = nil
+		}
+	}
+
+	for name, value := range plan {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value.ValueString()), &decoded); err != nil {
+			diags.AddAttributeError(
+				path.Root("custom_attributes").AtMapKey(name),
+				"Invalid custom attribute value",
+				fmt.Sprintf("The value of [%s] is not valid JSON, use jsonencode(): %s", name, err),
+			)
+			continue
+		}
+		values[name] = decoded
+	}
+
+	return values, diags
+}
+
+// Reads the values of the managed attributes (the keys of current) from the entity document.
+// The current value is kept when it is the same JSON, so formatting does not show as a change.
+func readCustomAttributeValues(current map[string]types.String, document map[string]interface{}) map[string]types.String {
+	if current == nil {
+		return nil
+	}
+
+	values := make(map[string]types.String, len(current))
+	for name, currentValue := range current {
+		encoded, err := json.Marshal(document[name])
+		if err != nil {
+			values[name] = currentValue
+			continue
+		}
+
+		normalized, err := biotplanmodifiers.NormalizeJSON(currentValue.ValueString())
+		if err == nil && normalized == string(encoded) {
+			values[name] = currentValue
+		} else {
+			values[name] = types.StringValue(string(encoded))
+		}
+	}
+	return values
+}
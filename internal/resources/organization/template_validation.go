@@ -0,0 +1,136 @@
+package organization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	"biot.com/terraform-provider-biot-gen2/internal/resources/template"
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+// Plan-time entry point of checkCustomAttributeValues for the resources with template_name and custom_attributes.
+// The template is read only on create and when template_name or custom_attributes change, so plans of unchanged
+// entities make no API calls. custom_attributes is read with GetAttribute, so values that are only known after apply
+// do not fail the read; when the whole map is unknown, it is not checked and a warning says so.
+func checkPlannedCustomAttributeValues(ctx context.Context, client *api.APIClient, entityType string, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var templateName types.String
+	var customAttributes types.Map
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("template_name"), &templateName)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("custom_attributes"), &customAttributes)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	creating := req.State.Raw.IsNull()
+	if !creating {
+		var stateTemplateName types.String
+		var stateCustomAttributes types.Map
+		diags.Append(req.State.GetAttribute(ctx, path.Root("template_name"), &stateTemplateName)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root("custom_attributes"), &stateCustomAttributes)...)
+		if diags.HasError() || (templateName.Equal(stateTemplateName) && customAttributes.Equal(stateCustomAttributes)) {
+			return diags
+		}
+	}
+
+	if customAttributes.IsUnknown() {
+		diags.AddAttributeWarning(
+			path.Root("custom_attributes"),
+			"Custom attribute values are not checked",
+			fmt.Sprintf("custom_attributes is only known after apply, so it is not checked against the %s template at plan time. BioT checks it on apply.", entityType),
+		)
+		return diags
+	}
+
+	values := map[string]types.String{}
+	if !customAttributes.IsNull() {
+		diags.Append(customAttributes.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	diags.Append(checkCustomAttributeValues(ctx, client, entityType, templateName, values, creating)...)
+	return diags
+}
+
+// Checks the configured custom attribute values against the template of the entity at plan time, instead of failing
+// the apply: every attribute must exist in the template and its value must match the attribute type.
+// On create, the mandatory attributes without a default value must be set.
+// Values that are only known after apply are not checked.
+func checkCustomAttributeValues(ctx context.Context, client *api.APIClient, entityType string, templateName types.String, values map[string]types.String, creating bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if templateName.IsNull() || templateName.IsUnknown() {
+		return diags
+	}
+
+	found, err := client.GetTemplateByTypeAndName(ctx, entityType, templateName.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("template_name"), "Template not found", fmt.Sprintf("Failed to find %s template [%s]: %s", entityType, templateName.ValueString(), err))
+		return diags
+	}
+
+	entityTemplate, err := client.GetTemplate(ctx, found.ID)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Failed to read %s template [%s]: %s", entityType, templateName.ValueString(), err))
+		return diags
+	}
+
+	attributes := make(map[string]api.BaseAttribute, len(entityTemplate.CustomAttributes))
+	for _, attribute := range entityTemplate.CustomAttributes {
+		// Type and name are decoded into the response fields, which shadow the embedded ones.
+		base := attribute.BaseAttribute
+		base.Name = attribute.Name
+		base.Type = attribute.Type
+		attributes[attribute.Name] = base
+	}
+
+	for _, name := range utils.SortedKeys(values) {
+		value := values[name]
+		attributePath := path.Root("custom_attributes").AtMapKey(name)
+
+		attribute, exists := attributes[name]
+		if !exists {
+			diags.AddAttributeError(attributePath, "Unknown custom attribute", fmt.Sprintf(
+				"The %s template [%s] does not have a custom attribute named [%s]. Custom attributes: %s",
+				entityType, templateName.ValueString(), name, strings.Join(utils.SortedKeys(attributes), ", "),
+			))
+			continue
+		}
+
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if detail := template.CheckAttributeValue(attribute, value.ValueString()); detail != "" {
+			diags.AddAttributeError(attributePath, "Invalid custom attribute value", detail)
+		}
+	}
+
+	if !creating {
+		return diags
+	}
+
+	for _, name := range utils.SortedKeys(attributes) {
+		attribute := attributes[name]
+		if _, configured := values[name]; configured || attribute.Validation == nil || attribute.Validation.Mandatory == nil || !*attribute.Validation.Mandatory {
+			continue
+		}
+		if attribute.Validation.DefaultValue != nil && *attribute.Validation.DefaultValue != "" {
+			continue
+		}
+
+		diags.AddAttributeError(path.Root("custom_attributes"), "Missing mandatory custom attribute", fmt.Sprintf(
+			"The custom attribute [%s] is mandatory in the %s template [%s], set it in custom_attributes.", name, entityType, templateName.ValueString(),
+		))
+	}
+
+	return diags
+}
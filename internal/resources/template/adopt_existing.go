@@ -0,0 +1,235 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+// With adopt_existing, a create of a template that already exists (same entity type and name, e.g. created in the
+// console or by an earlier apply that failed half way) takes over that template instead of failing, and applies the
+// planned configuration to it as an update.
+// The update would delete the custom and template attributes that are not configured (and their data), so the
+// template is only adopted when every one of them is configured; this is reported at plan time when possible.
+func (r *BiotTemplateResource) adoptExistingTemplate(ctx context.Context, plan TerraformTemplate) (api.TemplateResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	found, err := r.client.GetTemplateByTypeAndName(ctx, plan.EntityTypeName.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Template [%s] already exists, but it could not be read to adopt it: %s", plan.Name.ValueString(), err))
+		return api.TemplateResponse{}, diags
+	}
+
+	// Read again under the lock, the update sends the whole template.
+	defer lockTemplate(found.ID)()
+	existing, err := r.client.GetTemplate(ctx, found.ID)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Template [%s] already exists, but it could not be read to adopt it: %s", plan.Name.ValueString(), err))
+		return api.TemplateResponse{}, diags
+	}
+
+	existingParentID := mapToTerraformParentTemplateID(ctx, existing.ParentTemplate)
+	if !plan.ParentTemplateID.IsUnknown() && !plan.ParentTemplateID.Equal(existingParentID) {
+		diags.AddAttributeError(
+			path.Root("parent_template_id"),
+			"Can not adopt existing template",
+			fmt.Sprintf("Template [%s] already exists with parent template [%s], which can not be changed to [%s].", existing.Name, existingParentID.ValueString(), plan.ParentTemplateID.ValueString()),
+		)
+		return api.TemplateResponse{}, diags
+	}
+
+	diags.Append(checkUnconfiguredAttributes(existing, configuredAttributeNames(baseAttributesByCollection(plan)))...)
+	if diags.HasError() {
+		return api.TemplateResponse{}, diags
+	}
+
+	requestModel := plan
+	requestModel.ID = types.StringValue(existing.ID)
+
+	// Attributes that already exist keep their ID (and data), matched by name or by one of their previous_names.
+	forEachAttribute(TerraformTemplate{}, &requestModel, func(_ string, name string, _ *BaseTerraformAttribute, attribute *BaseTerraformAttribute) {
+		if isKnownString(attribute.ID) {
+			return
+		}
+		for _, candidate := range append([]types.String{types.StringValue(name)}, attribute.PreviousNames...) {
+			if id, found := findTemplateAttributeID(existing, candidate.ValueString()); found {
+				attribute.ID = types.StringValue(id)
+				return
+			}
+		}
+	})
+
+	// Builtin attributes that are not configured are computed by BioT, so the existing ones are kept.
+	if isDeclaredBuiltinAttributesManagement(plan) || len(plan.BuiltInAttributes) == 0 {
+		addUndeclaredBuiltinAttributes(ctx, &requestModel, existing)
+	}
+
+	updateRequest := MapTerraformTemplateToUpdateRequest(ctx, requestModel)
+	updateRequest.BaseDocument = existing.Raw
+
+	response, err := r.client.UpdateTemplate(ctx, existing.ID, updateRequest, false)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Failed to adopt existing template [%s]: %s", existing.Name, err))
+		return api.TemplateResponse{}, diags
+	}
+
+	diags.AddWarning(
+		"Adopted existing template",
+		fmt.Sprintf("Template [%s] of entity type [%s] already existed (id: %s), it is now managed by this resource and was updated to the planned configuration.", existing.Name, existing.EntityTypeName, existing.ID),
+	)
+
+	return response, diags
+}
+
+// Looked up before the create: the error BioT returns for a duplicate template is not part of its documented API,
+// so the conflict is not detected from the create error.
+func (r *BiotTemplateResource) templateToAdoptExists(ctx context.Context, plan TerraformTemplate) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	_, err := r.client.GetTemplateByTypeAndName(ctx, plan.EntityTypeName.ValueString(), plan.Name.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			return false, diags
+		}
+		diags.AddError("API Error", fmt.Sprintf("Failed to look up template [%s] to adopt: %s", plan.Name.ValueString(), err))
+		return false, diags
+	}
+
+	return true, diags
+}
+
+// Checks at plan time that an existing template with the planned entity type and name can be adopted.
+// Collections that are only known after apply are not checked here, the check is repeated on apply.
+func (r *BiotTemplateResource) checkAdoptExisting(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var adoptExisting types.Bool
+	var entityType, name types.String
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("adopt_existing"), &adoptExisting)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("entity_type"), &entityType)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if diags.HasError() || !adoptExisting.ValueBool() || !isKnownString(entityType) || !isKnownString(name) {
+		return diags
+	}
+
+	existing, err := r.client.GetTemplateByTypeAndName(ctx, entityType.ValueString(), name.ValueString())
+	if err != nil {
+		if !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			tflog.Warn(ctx, "Could not look up the template to adopt, it is checked on apply", map[string]interface{}{
+				"template_name": name.ValueString(),
+				"error":         err.Error(),
+			})
+		}
+		return diags
+	}
+
+	diags.Append(checkUnconfiguredAttributes(existing, plannedAttributeNames(ctx, req.Plan))...)
+	return diags
+}
+
+// Reports the custom and template attributes of the existing template that are not configured, adopting the
+// template would delete them. configured holds the configured names and previous_names by collection.
+func checkUnconfiguredAttributes(existing api.TemplateResponse, configured map[string]map[string]bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	unconfigured := attributesNotIn(existing, configured)
+	if len(unconfigured) == 0 {
+		return diags
+	}
+
+	diags.AddError(
+		"Can not adopt existing template",
+		fmt.Sprintf(
+			"Template [%s] of entity type [%s] already exists (id: %s) with attributes that are not configured:\n  - %s\n\n"+
+				"Adopting it would delete these attributes and their data. Add them to the configuration, or delete them from the template.",
+			existing.Name, existing.EntityTypeName, existing.ID, strings.Join(unconfigured, "\n  - "),
+		),
+	)
+	return diags
+}
+
+// Names of the custom and template attributes of the existing template that are not configured.
+// An attribute is configured when its name is a key or a previous name in any collection. The attributes of a
+// collection that is missing from configured (only known after apply) are not reported.
+func attributesNotIn(existing api.TemplateResponse, configured map[string]map[string]bool) []string {
+	names := map[string]bool{}
+	for _, collectionNames := range configured {
+		for name := range collectionNames {
+			names[name] = true
+		}
+	}
+
+	unconfigured := map[string]bool{}
+	if _, known := configured["custom_attributes"]; known {
+		for _, attribute := range existing.CustomAttributes {
+			if !names[attribute.Name] {
+				unconfigured[attribute.Name] = true
+			}
+		}
+	}
+	if _, known := configured["template_attributes"]; known {
+		for _, attribute := range existing.TemplateAttributes {
+			if !names[attribute.Name] {
+				unconfigured[attribute.Name] = true
+			}
+		}
+	}
+	return utils.SortedKeys(unconfigured)
+}
+
+func configuredAttributeNames(attributes map[string]map[string]BaseTerraformAttribute) map[string]map[string]bool {
+	result := map[string]map[string]bool{}
+	for collection, collectionAttributes := range attributes {
+		result[collection] = map[string]bool{}
+		for name, attribute := range collectionAttributes {
+			result[collection][name] = true
+			for _, previousName := range attribute.PreviousNames {
+				result[collection][previousName.ValueString()] = true
+			}
+		}
+	}
+	return result
+}
+
+// Same as configuredAttributeNames, read from the plan: only the keys and previous_names have to be known,
+// collections that are unknown are left out.
+func plannedAttributeNames(ctx context.Context, plan tfsdk.Plan) map[string]map[string]bool {
+	result := map[string]map[string]bool{}
+	for _, collection := range attributeCollections {
+		var attributes types.Map
+		if plan.GetAttribute(ctx, path.Root(collection), &attributes).HasError() || attributes.IsUnknown() {
+			continue
+		}
+
+		result[collection] = map[string]bool{}
+		for name, element := range attributes.Elements() {
+			result[collection][name] = true
+
+			object, ok := element.(types.Object)
+			if !ok {
+				continue
+			}
+			previousNames, ok := object.Attributes()["previous_names"].(types.List)
+			if !ok {
+				continue
+			}
+			for _, previousName := range previousNames.Elements() {
+				if value, ok := previousName.(types.String); ok && isKnownString(value) {
+					result[collection][value.ValueString()] = true
+				}
+			}
+		}
+	}
+	return result
+}
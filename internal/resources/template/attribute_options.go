@@ -0,0 +1,87 @@
+package template
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+// Calls fn for every attribute of target (ordered by collection and name), with the attribute of the same name in
+// source (nil when there is none).
+func forEachAttribute(source TerraformTemplate, target *TerraformTemplate, fn func(collection string, name string, source *BaseTerraformAttribute, target *BaseTerraformAttribute)) {
+	sourceAttributes := baseAttributesByCollection(source)
+
+	apply := func(collection string, name string, target *BaseTerraformAttribute) {
+		if sourceAttribute, ok := sourceAttributes[collection][name]; ok {
+			fn(collection, name, &sourceAttribute, target)
+		} else {
+			fn(collection, name, nil, target)
+		}
+	}
+
+	for _, name := range utils.SortedKeys(target.BuiltInAttributes) {
+		attribute := target.BuiltInAttributes[name]
+		apply("builtin_attributes", name, &attribute.BaseTerraformAttribute)
+		target.BuiltInAttributes[name] = attribute
+	}
+	for _, name := range utils.SortedKeys(target.CustomAttributes) {
+		attribute := target.CustomAttributes[name]
+		apply("custom_attributes", name, &attribute.BaseTerraformAttribute)
+		target.CustomAttributes[name] = attribute
+	}
+	for _, name := range utils.SortedKeys(target.TemplateAttributes) {
+		attribute := target.TemplateAttributes[name]
+		apply("template_attributes", name, &attribute.BaseTerraformAttribute)
+		target.TemplateAttributes[name] = attribute
+	}
+}
+
+// Copies the attribute fields that BioT does not return as configured from the plan / state (source) to the
+// attributes mapped from the BioT response (target).
+func copyAttributeOptions(source TerraformTemplate, target *TerraformTemplate) {
+	forEachAttribute(source, target, func(_ string, _ string, sourceAttribute *BaseTerraformAttribute, targetAttribute *BaseTerraformAttribute) {
+		if sourceAttribute == nil {
+			targetAttribute.AllowedValues = nil
+			return
+		}
+
+		// previous_names is a configuration-only field.
+		targetAttribute.PreviousNames = sourceAttribute.PreviousNames
+		targetAttribute.AllowedValues = keepConfiguredAllowedValues(sourceAttribute.AllowedValues, targetAttribute.AllowedValues)
+
+		// The names are resolved to IDs on apply, BioT returns only the IDs.
+		if sourceAttribute.LinkConfiguration != nil && targetAttribute.LinkConfiguration != nil {
+			targetAttribute.LinkConfiguration.TemplateName = sourceAttribute.LinkConfiguration.TemplateName
+			targetAttribute.LinkConfiguration.AttributeName = sourceAttribute.LinkConfiguration.AttributeName
+		}
+		if sourceAttribute.ReferenceConfiguration != nil && targetAttribute.ReferenceConfiguration != nil {
+			targetAttribute.ReferenceConfiguration.ValidTemplateNames = sourceAttribute.ReferenceConfiguration.ValidTemplateNames
+		}
+	})
+}
+
+// BioT returns the whole catalog for Timezone / Locale attributes that are not restricted, so the values are kept in
+// state only when allowed_values is configured. When BioT returns the configured values (in any order), the
+// configured list is kept as is, otherwise the actual values are stored so the drift shows in the plan.
+func keepConfiguredAllowedValues(configured []types.String, actual []types.String) []types.String {
+	if configured == nil {
+		return nil
+	}
+
+	if slices.Equal(sortedStrings(configured), sortedStrings(actual)) {
+		return configured
+	}
+
+	return actual
+}
+
+func sortedStrings(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.ValueString())
+	}
+	slices.Sort(result)
+	return result
+}
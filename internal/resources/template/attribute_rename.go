@@ -0,0 +1,90 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Attributes are keyed by name, so a renamed attribute has no state and would get a new ID, which makes BioT delete
+// the old attribute (and its data) and create a new one.
+// When the new attribute lists the old name in previous_names, its ID is carried forward from the state of the old
+// attribute, so BioT renames the attribute in place.
+type previousNamesIDPlanModifier struct{}
+
+func (m previousNamesIDPlanModifier) Description(_ context.Context) string {
+	return "Copies the ID of a renamed attribute from the state of one of its previous_names."
+}
+
+func (m previousNamesIDPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m previousNamesIDPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Resource is being created or destroyed, or the attribute already has an ID
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.PlanValue.IsUnknown() || !req.StateValue.IsNull() {
+		return
+	}
+
+	attributePath := req.Path.ParentPath()
+	collectionPath := attributePath.ParentPath()
+
+	attributeName, ok := mapKeyOf(attributePath)
+	if !ok {
+		return
+	}
+
+	var previousNames types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, attributePath.AtName("previous_names"), &previousNames)...)
+	if resp.Diagnostics.HasError() || previousNames.IsNull() || previousNames.IsUnknown() {
+		return
+	}
+
+	var configAttributes types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, collectionPath, &configAttributes)...)
+	var stateAttributes types.Map
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, collectionPath, &stateAttributes)...)
+	if resp.Diagnostics.HasError() || stateAttributes.IsNull() {
+		return
+	}
+
+	for _, element := range previousNames.Elements() {
+		previousName, ok := element.(types.String)
+		if !ok || previousName.IsNull() || previousName.IsUnknown() {
+			continue
+		}
+
+		// The old attribute is still configured, so it is not renamed.
+		if _, stillConfigured := configAttributes.Elements()[previousName.ValueString()]; stillConfigured {
+			continue
+		}
+
+		previousAttribute, ok := stateAttributes.Elements()[previousName.ValueString()].(types.Object)
+		if !ok || previousAttribute.IsNull() {
+			continue
+		}
+
+		previousID, ok := previousAttribute.Attributes()["id"].(types.String)
+		if !ok || previousID.IsNull() || previousID.IsUnknown() {
+			continue
+		}
+
+		resp.PlanValue = previousID
+		resp.Diagnostics.AddAttributeWarning(
+			attributePath,
+			"Attribute will be renamed",
+			fmt.Sprintf("Attribute [%s] will be renamed to [%s] in place. It keeps its ID [%s] and its data.", previousName.ValueString(), attributeName, previousID.ValueString()),
+		)
+		return
+	}
+}
+
+// Returns the map key of the last step of the path, e.g. "weight" for custom_attributes["weight"].
+func mapKeyOf(p path.Path) (string, bool) {
+	step, _ := p.Steps().LastStep()
+	key, ok := step.(path.PathStepElementKeyString)
+	return string(key), ok
+}
@@ -9,15 +9,21 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"biot.com/terraform-provider-biot-gen2/internal/api"
 	biotplanmodifiers "biot.com/terraform-provider-biot-gen2/internal/resources/biot_plan_modifiers"
+	biotvalidators "biot.com/terraform-provider-biot-gen2/internal/resources/biot_validators"
 )
 
 func NewResource() resource.Resource {
@@ -28,8 +34,16 @@ type BiotTemplateResource struct {
 	client *api.APIClient
 }
 
+var _ resource.ResourceWithModifyPlan = &BiotTemplateResource{}
+var _ resource.ResourceWithValidateConfig = &BiotTemplateResource{}
+var _ resource.ResourceWithUpgradeState = &BiotTemplateResource{}
+var _ resource.ResourceWithIdentity = &BiotTemplateResource{}
+var _ resource.ResourceWithImportState = &BiotTemplateResource{}
+
 func (r *BiotTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = "biot_template"
+	// The identity is entity_type + name, and the template name can be changed.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 // The response of this function is passed to every template resouce when running create / read / update / delete.
@@ -49,90 +63,251 @@ func (r *BiotTemplateResource) Configure(ctx context.Context, req resource.Confi
 }
 
 func (r *BiotTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
+	attributes := templateDefinitionSchema()
+	attributes["validate_on_plan"] = schema.BoolAttribute{
+		Optional:    true,
+		Description: "When true, the create / update request is validated by BioT (dry-run) during plan, and any errors are reported as plan errors. Falls back to local checks (e.g. regex, duplicate names, type-specific fields) when the BioT validation endpoint is not available. Skipped when the template does not change.",
+	}
+	attributes["deletion_protection"] = schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "When true, the template can not be deleted (e.g. by `terraform destroy` or by moving the resource to a new address), " +
+			"even with `force_delete`. There is no default: when false or not set, a template with entities is still protected, " +
+			"because the entities are counted before every delete and the delete is refused unless `force_delete` is true. " +
+			"Must be applied before the template is deleted.",
+	}
+	attributes["adopt_existing"] = schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "When true and a template with the same `entity_type` and `name` already exists, it is adopted on create: " +
+			"the existing template (and the attributes with the same names, including their data) is managed by this resource " +
+			"and updated to the configuration, instead of failing. Requires `entity_type`. " +
+			"The template is not adopted while it has custom or template attributes that are not configured (by name or in `previous_names`), " +
+			"since adopting it would delete them and their data; this is reported at plan time when the template already exists.",
+	}
+	attributes["fail_on_drift"] = schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "Changes made to the template outside Terraform (e.g. in the console) are reported as a warning by the first refresh that finds them. " +
+			"When true, a plan that leaves any of the changes in place fails, including an update that does not change the drifted fields; " +
+			"once applied, the template is the new baseline. To keep the changes, set `fail_on_drift = false` for one apply.",
+	}
+	attributes["wait_for_consistency"] = schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "When true, create / update wait until reading the template returns what was written, " +
+			"so resources that depend on the template do not read stale data. Bounded by `timeouts`.",
+	}
+	attributes["wait_for_analytics_db_sync"] = schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "When true, create / update wait until BioT reports that the template is synced to the analytics DB " +
+			"(the columns of `analytics_db_configuration` exist). Bounded by `timeouts`.",
+	}
+	attributes["timeouts"] = schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "How long create / update wait for `wait_for_consistency` and `wait_for_analytics_db_sync`, e.g. `30s`, `10m`. Defaults to `10m`.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed:    true,
-				Description: "The ID of the template.",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+			"create": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{biotvalidators.Duration()},
+			},
+			"update": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{biotvalidators.Duration()},
+			},
+		},
+	}
+	attributes["force_delete"] = schema.BoolAttribute{
+		Optional: true,
+		MarkdownDescription: "Before a template is deleted, the entities that use it are counted, and the delete is refused when there are any, " +
+			"since deleting a template also deletes its entities. Set to true (and apply) to delete the template anyway. " +
+			"Does not override `deletion_protection = true`.",
+	}
+
+	resp.Schema = schema.Schema{
+		// Version 1: attribute collections are maps keyed by attribute name (were sets in version 0).
+		// Version 2: the attribute category is an object (was the category name in version 1).
+		Version: 2,
+		MarkdownDescription: "A BioT template. Can be imported by template ID, by `entity-type:template-name`, or with an `import` block `identity` " +
+			"(`entity_type`, `name`; Terraform 1.12 or later). Existing templates can be listed with `terraform query` (Terraform 1.14 or later).",
+		Attributes: attributes,
+	}
+}
+
+// The attributes of a template, shared by biot_template and the templates of biot_template_set.
+func templateDefinitionSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the template.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"display_name": schema.StringAttribute{
+			Required: true,
+		},
+		"name": schema.StringAttribute{
+			Required: true,
+		},
+		"description": schema.StringAttribute{
+			Optional: true,
+		},
+		"owner_organization_id": schema.StringAttribute{
+			Optional: true,
+		},
+		"analytics_db_configuration": schema.SingleNestedAttribute{
+			Optional: true,
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Optional: true,
 				},
 			},
-			"display_name": schema.StringAttribute{
-				Required: true,
+		},
+		// entity_type and parent_template_id can not be changed on an existing template:
+		// they are sent on create only, UpdateTemplateRequest has neither of them.
+		"entity_type": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: fmt.Sprintf("The entity type of the template. Known values: %s. Changing it replaces the template.", biotvalidators.FormatMarkdownValues(entityTypes)),
+			Validators: []validator.String{
+				biotvalidators.KnownValuesWithSuggestion(entityTypes),
 			},
-			"name": schema.StringAttribute{
-				Required: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				templateReplaceModifier,
 			},
-			"description": schema.StringAttribute{
-				Optional: true,
+		},
+		"parent_template_id": schema.StringAttribute{
+			Optional:    true,
+			Description: "The ID of the parent template. Changing it replaces the template.",
+			PlanModifiers: []planmodifier.String{
+				templateReplaceModifier,
 			},
-			"owner_organization_id": schema.StringAttribute{
-				Optional: true,
+		},
+		"parent_template": schema.SingleNestedAttribute{
+			Computed:    true,
+			Description: "The parent template, as returned by BioT.",
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
 			},
-			"analytics_db_configuration": schema.SingleNestedAttribute{
-				Optional: true,
-				Computed: true,
-				Attributes: map[string]schema.Attribute{
-					"name": schema.StringAttribute{
-						Optional: true,
-					},
-				},
+			Attributes: map[string]schema.Attribute{
+				"id":           schema.StringAttribute{Computed: true},
+				"name":         schema.StringAttribute{Computed: true},
+				"display_name": schema.StringAttribute{Computed: true},
 			},
-			"entity_type": schema.StringAttribute{
-				Optional: true,
+		},
+		"builtin_attributes": schema.MapNestedAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Builtin attributes associated with the template, keyed by attribute name. When builtin_attributes_management is \"declared\", only the configured builtin attributes are tracked.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: builtinAttributeSchema(),
 			},
-			"parent_template_id": schema.StringAttribute{
-				Optional: true,
+			PlanModifiers: []planmodifier.Map{
+				declaredBuiltinAttributesPlanModifier{},
 			},
-			"builtin_attributes": schema.SetNestedAttribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "Builtin attributes associated with the template.",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: builtinAttributeSchema(),
-				},
-				PlanModifiers: []planmodifier.Set{
-					biotplanmodifiers.CopyIDFromStateByNameSetModifier{},
-				},
+		},
+		"custom_attributes": schema.MapNestedAttribute{
+			Optional:    true,
+			Description: "Custom attributes associated with the template, keyed by attribute name.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: customAttributeSchema(),
 			},
-			"custom_attributes": schema.SetNestedAttribute{
-				Optional:    true,
-				Description: "Custom attributes associated with the template.",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: customAttributeSchema(),
-				},
-				PlanModifiers: []planmodifier.Set{
-					biotplanmodifiers.CopyIDFromStateByNameSetModifier{},
-				},
+		},
+		"template_attributes": schema.MapNestedAttribute{
+			Optional:    true,
+			Computed:    true,
+			Description: "Template attributes associated with the template, keyed by attribute name.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: templateAttributeSchema(),
 			},
-			"template_attributes": schema.SetNestedAttribute{
-				Optional:    true,
-				Computed:    true,
-				Description: "Template attributes associated with the template.",
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: templateAttributeSchema(),
-				},
-				PlanModifiers: []planmodifier.Set{
-					biotplanmodifiers.CopyIDFromStateByNameSetModifier{},
-				},
+		},
+		"builtin_attributes_management": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  stringdefault.StaticString(builtinAttributesManagementAll),
+			MarkdownDescription: "How builtin attributes are tracked. `all` (default) tracks every builtin attribute of the template. " +
+				"`declared` tracks only the builtin attributes in `builtin_attributes`, the rest are left untouched on update.",
+			Validators: []validator.String{
+				biotvalidators.OneOfWithSuggestion(builtinAttributesManagementModes),
 			},
 		},
 	}
 }
 
+var templateReplaceModifier = biotplanmodifiers.RequiresReplaceWithWarningModifier{
+	WarningSummary: "Template will be replaced",
+	WarningDetail: "This attribute can not be changed on an existing template, so the template will be deleted and created again. " +
+		"Deleting a template also deletes ALL the entities (and their data) that were created from it.",
+}
+
 func attributeSchema() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"id": schema.StringAttribute{
 			Computed: true,
-			// Plan-modifier is implemeneted from the "parent" attribute.
+			// Attributes are keyed by name, so the ID is kept as long as the attribute name does not change,
+			// or the old name is listed in previous_names.
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+				previousNamesIDPlanModifier{},
+			},
+		},
+		"allowed_values": schema.SetAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			MarkdownDescription: "Restricts the values of a `TIMEZONE` (IANA time zone IDs, e.g. `Europe/London`) or `LOCALE` (e.g. `en-US`) attribute " +
+				"to a subset of the platform catalog. When not set, every value of the catalog is allowed.",
+		},
+		"previous_names": schema.ListAttribute{
+			ElementType: types.StringType,
+			Optional:    true,
+			MarkdownDescription: "Names this attribute had before. When the attribute is renamed (its key changes) and the old name is listed here, " +
+				"the attribute is renamed in place and keeps its ID and data, instead of being deleted and created again. " +
+				"Not supported for builtin attributes.",
 		},
 		"display_name": schema.StringAttribute{Optional: true},
 		"phi":          schema.BoolAttribute{Optional: true},
-		"name":         schema.StringAttribute{Required: true},
-		"type":         schema.StringAttribute{Required: true},
-		"category":     schema.StringAttribute{Optional: true},
-		"base_path":    schema.StringAttribute{Optional: true},
+		"validation_metadata": schema.SingleNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "What BioT allows to change on the attribute. `mandatory` can not be changed when `mandatory_read_only` is true, and `phi` can not be changed when `phi_read_only` is true.",
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"mandatory_read_only": schema.BoolAttribute{Computed: true},
+				"system_mandatory":    schema.BoolAttribute{Computed: true},
+				"phi_read_only":       schema.BoolAttribute{Computed: true},
+			},
+		},
+		"type": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: fmt.Sprintf("The attribute type. Known values: %s.", biotvalidators.FormatMarkdownValues(sortedAttributeTypes())),
+			Validators: []validator.String{
+				biotvalidators.KnownValuesWithSuggestion(sortedAttributeTypes()),
+			},
+		},
+		"category": schema.SingleNestedAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "The category of the attribute. When not set, BioT keeps the current category, or assigns `REGULAR` to a new attribute.",
+			PlanModifiers: []planmodifier.Object{
+				objectplanmodifier.UseStateForUnknown(),
+			},
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: fmt.Sprintf("The category name. Known values: %s. `REGULAR` is supported by every entity type, the others only by some of them.", biotvalidators.FormatMarkdownValues(allAttributeCategories())),
+					Validators: []validator.String{
+						biotvalidators.KnownValuesWithSuggestion(allAttributeCategories()),
+					},
+				},
+				"display_name": schema.StringAttribute{
+					Computed: true,
+					PlanModifiers: []planmodifier.String{
+						biotplanmodifiers.UseStateWhenSiblingsUnchangedModifier{Siblings: []string{"name"}},
+					},
+				},
+			},
+		},
+		"base_path": schema.StringAttribute{Optional: true},
 
 		"reference_configuration": schema.SingleNestedAttribute{
 			Optional: true,
@@ -143,17 +318,64 @@ func attributeSchema() map[string]schema.Attribute {
 				"valid_templates_to_reference": schema.ListAttribute{
 					ElementType: types.StringType,
 					Optional:    true,
+					Computed:    true,
+					Description: "IDs of the templates that can be referenced. Resolved from valid_template_names when those are set.",
+					PlanModifiers: []planmodifier.List{
+						biotplanmodifiers.UseStateWhenSiblingsUnchangedModifier{Siblings: []string{"valid_template_names", "entity_type"}},
+					},
+				},
+				"valid_template_names": schema.ListAttribute{
+					ElementType: types.StringType,
+					Optional:    true,
+					Description: "Names of the templates (of entity_type) that can be referenced, resolved to valid_templates_to_reference on apply. Use instead of template IDs, which differ between environments.",
+				},
+				"entity_type": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: fmt.Sprintf("The entity type of the referenced templates. Known values: %s.", biotvalidators.FormatMarkdownValues(entityTypes)),
+					Validators: []validator.String{
+						biotvalidators.KnownValuesWithSuggestion(entityTypes),
+					},
 				},
-				"entity_type": schema.StringAttribute{Optional: true},
 			},
 		},
 
 		"link_configuration": schema.SingleNestedAttribute{
 			Optional: true,
 			Attributes: map[string]schema.Attribute{
-				"entity_type_name": schema.StringAttribute{Optional: true},
-				"template_id":      schema.StringAttribute{Optional: true},
-				"attribute_id":     schema.StringAttribute{Optional: true},
+				"entity_type_name": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: fmt.Sprintf("The entity type of the linked template. Known values: %s.", biotvalidators.FormatMarkdownValues(entityTypes)),
+					Validators: []validator.String{
+						biotvalidators.KnownValuesWithSuggestion(entityTypes),
+					},
+				},
+				"template_id": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The ID of the linked template. Resolved from template_name and entity_type_name when template_name is set.",
+					PlanModifiers: []planmodifier.String{
+						biotplanmodifiers.UseStateWhenSiblingsUnchangedModifier{Siblings: []string{"template_name", "entity_type_name"}},
+					},
+				},
+				"attribute_id": schema.StringAttribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "The ID of the linked attribute. Resolved from attribute_name when it is set.",
+					PlanModifiers: []planmodifier.String{
+						biotplanmodifiers.UseStateWhenSiblingsUnchangedModifier{
+							Siblings:         []string{"attribute_name", "template_name", "entity_type_name"},
+							ComputedSiblings: []string{"template_id"},
+						},
+					},
+				},
+				"template_name": schema.StringAttribute{
+					Optional:    true,
+					Description: "The name of the linked template (of entity_type_name), resolved to template_id on apply. Use instead of template_id, which differs between environments.",
+				},
+				"attribute_name": schema.StringAttribute{
+					Optional:    true,
+					Description: "The name of the linked attribute in the linked template, resolved to attribute_id on apply.",
+				},
 			},
 		},
 
@@ -180,15 +402,29 @@ func attributeSchema() map[string]schema.Attribute {
 				"units":       schema.StringAttribute{Optional: true},
 				"upper_range": schema.NumberAttribute{Optional: true},
 				"lower_range": schema.NumberAttribute{Optional: true},
-				"sub_type":    schema.StringAttribute{Optional: true},
+				"sub_type": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: fmt.Sprintf("The numeric sub type. Known values: %s.", biotvalidators.FormatMarkdownValues(numericSubTypes)),
+					Validators: []validator.String{
+						biotvalidators.KnownValuesWithSuggestion(numericSubTypes),
+					},
+				},
 			},
 		},
 
 		"selectable_values": schema.SetNestedAttribute{
 			Optional: true,
+			PlanModifiers: []planmodifier.Set{
+				biotplanmodifiers.CopyIDFromStateSetModifier{},
+			},
 			NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
-					"id":           schema.StringAttribute{Computed: true},
+					"id": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Description: "The ID of the selectable value. Computed by BioT, or set explicitly to keep the identity of the value when it is renamed " +
+							"(values are otherwise matched by name, or by display_name when renamed).",
+					},
 					"name":         schema.StringAttribute{Required: true},
 					"display_name": schema.StringAttribute{Optional: true},
 				},
@@ -240,6 +476,19 @@ func templateAttributeSchema() map[string]schema.Attribute {
 	attrSchema := make(map[string]schema.Attribute, len(base)+2)
 	maps.Copy(attrSchema, base)
 
+	// BioT assigns the category of template attributes, it is not part of the request.
+	attrSchema["category"] = schema.SingleNestedAttribute{
+		Computed:    true,
+		Description: "The category of the attribute, assigned by BioT.",
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.UseStateForUnknown(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"name":         schema.StringAttribute{Computed: true},
+			"display_name": schema.StringAttribute{Computed: true},
+		},
+	}
+
 	attrSchema["value_json"] = schema.StringAttribute{
 		Optional:    true,
 		Description: "Value as JSON string (used instead of DynamicAttribute due to Terraform limitations)",
@@ -292,8 +541,15 @@ func (r *BiotTemplateResource) Read(ctx context.Context, req resource.ReadReques
 
 	// Update state
 	templateModel := mapTemplateResponseToTerrformModel(ctx, getTemplateResponse)
+	copyResourceOptions(state, &templateModel)
+	if isDeclaredBuiltinAttributesManagement(state) {
+		keepDeclaredBuiltinAttributes(&templateModel, state.BuiltInAttributes)
+	}
 	diags = resp.State.Set(ctx, templateModel)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setTemplateIdentity(ctx, resp.Identity, templateModel)...)
+	resp.Diagnostics.Append(saveTemplateDocument(ctx, resp.Private, getTemplateResponse)...)
+	resp.Diagnostics.Append(reportTemplateDrift(ctx, req.Private, resp.Private, templateModel)...)
 }
 
 func (r *BiotTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -306,16 +562,53 @@ func (r *BiotTemplateResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	createRequest := MapTerraformTemplateToCreateRequest(ctx, plan)
-	response, err := r.client.CreateTemplate(ctx, createRequest)
+	resp.Diagnostics.Append(resolveAttributeReferences(ctx, r.client, path.Empty(), &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists := false
+	if plan.AdoptExisting.ValueBool() {
+		var lookupDiags diag.Diagnostics
+		exists, lookupDiags = r.templateToAdoptExists(ctx, plan)
+		resp.Diagnostics.Append(lookupDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	var response api.TemplateResponse
+	var err error
+	if exists {
+		var adoptDiags diag.Diagnostics
+		response, adoptDiags = r.adoptExistingTemplate(ctx, plan)
+		resp.Diagnostics.Append(adoptDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		createRequest := MapTerraformTemplateToCreateRequest(ctx, plan)
+		response, err = r.client.CreateTemplate(ctx, createRequest)
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to create template: %s", err))
 		return
 	}
 
-	diags = resp.State.Set(ctx, mapTemplateResponseToTerrformModel(ctx, response))
+	templateModel := mapTemplateResponseToTerrformModel(ctx, response)
+	copyResourceOptions(plan, &templateModel)
+	if isDeclaredBuiltinAttributesManagement(plan) {
+		keepDeclaredBuiltinAttributes(&templateModel, plan.BuiltInAttributes)
+	}
+	diags = resp.State.Set(ctx, templateModel)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setTemplateIdentity(ctx, resp.Identity, templateModel)...)
+	resp.Diagnostics.Append(saveTemplateDocument(ctx, resp.Private, response)...)
+	resp.Diagnostics.Append(saveAppliedPayload(ctx, resp.Private, templateModel)...)
+
+	// After the state is saved, so the template is tracked even when waiting fails.
+	resp.Diagnostics.Append(r.waitAfterWrite(ctx, plan, plan, response, "create")...)
 }
 
 func (r *BiotTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -325,46 +618,77 @@ func (r *BiotTemplateResource) Update(ctx context.Context, req resource.UpdateRe
 	req.Plan.Get(ctx, &plan)
 	req.State.Get(ctx, &state)
 
-	forceUpdateString := os.Getenv("TF_FORCE_UPDATE")
-	var forceUpdate = false
-	var err error
+	// Attributes of the template can also be updated by biot_template_attribute resources.
+	defer lockTemplate(state.ID.ValueString())()
 
-	if forceUpdateString != "" {
-		forceUpdate, err = strconv.ParseBool(forceUpdateString)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Invalid TF_FORCE_UPDATE value",
-				fmt.Sprintf("Value [%q] is not a valid boolean (expected: true / false)", forceUpdateString),
-			)
-			return
-		}
+	forceUpdate, diags := forceUpdateFromEnv()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid TF_FORCE_UPDATE value: [%q], should be boolean (true / false)", forceUpdateString)
+	// The update is merged over the last template document read from BioT, so fields the provider does not model
+	// (e.g. settings made in the console) are not reset.
+	baseDocument, diags := loadTemplateDocument(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resolveAttributeReferences(ctx, r.client, path.Empty(), &plan)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	updateRequest := MapTerraformTemplateToUpdateRequest(ctx, plan)
+	requestModel := plan
+	if isDeclaredBuiltinAttributesManagement(plan) {
+		current, err := r.client.GetTemplate(ctx, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read template before update: %s", err))
+			return
+		}
+		addUndeclaredBuiltinAttributes(ctx, &requestModel, current)
+		baseDocument = current.Raw
+	}
+
+	updateRequest := MapTerraformTemplateToUpdateRequest(ctx, requestModel)
+	updateRequest.BaseDocument = baseDocument
 	response, err := r.client.UpdateTemplate(ctx, state.ID.ValueString(), updateRequest, forceUpdate)
 
 	if err != nil {
 		if apiError, ok := api.ConvertAPIError(err); ok && apiError.Code == "CUSTOM_ATTRIBUTE_IN_USE" {
-			formatCustomAttributeInUseError(apiError, resp)
+			formatCustomAttributeInUseError(apiError, &resp.Diagnostics)
 		} else {
 			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to update template: %s", err))
 		}
 		return
 	}
 
-	diags := resp.State.Set(ctx, mapTemplateResponseToTerrformModel(ctx, response))
+	templateModel := mapTemplateResponseToTerrformModel(ctx, response)
+	copyResourceOptions(plan, &templateModel)
+	if isDeclaredBuiltinAttributesManagement(plan) {
+		keepDeclaredBuiltinAttributes(&templateModel, plan.BuiltInAttributes)
+	}
+	diags = resp.State.Set(ctx, templateModel)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setTemplateIdentity(ctx, resp.Identity, templateModel)...)
+	resp.Diagnostics.Append(saveTemplateDocument(ctx, resp.Private, response)...)
+	resp.Diagnostics.Append(saveAppliedPayload(ctx, resp.Private, templateModel)...)
+	resp.Diagnostics.Append(r.waitAfterWrite(ctx, plan, requestModel, response, "update")...)
 }
 
 func (r *BiotTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state TerraformTemplate
 
-	req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTemplateDeletionAllowed(ctx, r.client, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	client := r.client
 	err := client.DeleteTemplate(ctx, state.ID.ValueString())
@@ -373,7 +697,189 @@ func (r *BiotTemplateResource) Delete(ctx context.Context, req resource.DeleteRe
 	}
 }
 
-func formatCustomAttributeInUseError(apiError api.APIError, resp *resource.UpdateResponse) {
+// Offline, type-aware validation of the attributes, runs on every validate / plan.
+func (r *BiotTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateTemplateConfigAttributes(ctx, path.Empty(), req.Config.GetAttribute)...)
+
+	var adoptExisting types.Bool
+	var entityType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("adopt_existing"), &adoptExisting)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("entity_type"), &entityType)...)
+	// The existing template is looked up by entity type and name.
+	if adoptExisting.ValueBool() && entityType.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("entity_type"), "Missing entity_type", "entity_type is required when adopt_existing is true")
+	}
+}
+
+func (r *BiotTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed, or the provider is not configured yet (e.g. during validate).
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(r.checkAdoptExisting(ctx, req)...)
+	} else {
+		resp.Diagnostics.Append(failOnDrift(ctx, req)...)
+
+		var state TerraformTemplate
+		if !req.State.Get(ctx, &state).HasError() {
+			// Planned attributes with values that are only known after apply are not compared.
+			resp.Diagnostics.Append(checkReadOnlyAttributeChanges(ctx, path.Empty(), state, knownPlannedAttributes(ctx, req.Plan, path.Empty()))...)
+		}
+		resp.Diagnostics.Append(r.warnAboutRemovedSelectableValuesInUse(ctx, req)...)
+	}
+
+	var validateOnPlan types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("validate_on_plan"), &validateOnPlan)...)
+	if resp.Diagnostics.HasError() || !validateOnPlan.ValueBool() {
+		return
+	}
+
+	// Nothing changes (e.g. a refresh-only plan), the template was already validated when it was applied.
+	if !req.State.Raw.IsNull() && req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	resp.Diagnostics.Append(r.validateTemplatePlan(ctx, req)...)
+}
+
+// Sends the create / update request to BioT as a dry-run, and falls back to local checks (e.g. regex, type-specific fields)
+// when the validation endpoint is not available.
+func (r *BiotTemplateResource) validateTemplatePlan(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var err error
+	var localChecks func() diag.Diagnostics
+
+	if req.State.Raw.IsNull() {
+		// Built from the configuration: the computed collections of the plan (e.g. builtin_attributes) are unknown on create.
+		var config TerraformTemplate
+		if req.Config.Get(ctx, &config).HasError() {
+			return skippedTemplateValidation()
+		}
+
+		diags.Append(resolveAttributeReferences(ctx, r.client, path.Empty(), &config)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		createRequest := MapTerraformTemplateToCreateRequest(ctx, config)
+		err = r.client.ValidateCreateTemplate(ctx, createRequest)
+		localChecks = func() diag.Diagnostics {
+			return validateTemplateRequestLocally(createRequest.BuiltInAttributes, createRequest.CustomAttributes, createRequest.TemplateAttributes)
+		}
+	} else {
+		// Built from the plan, which carries the attribute IDs of the state, so renamed attributes are not seen as new ones.
+		var plan, state TerraformTemplate
+		if req.Plan.Get(ctx, &plan).HasError() {
+			return skippedTemplateValidation()
+		}
+		diags.Append(req.State.Get(ctx, &state)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		diags.Append(resolveAttributeReferences(ctx, r.client, path.Empty(), &plan)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		// The same document Update sends: merged over the last template document read from BioT.
+		baseDocument, d := loadTemplateDocument(ctx, req.Private)
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+
+		updateRequest := MapTerraformTemplateToUpdateRequest(ctx, plan)
+		updateRequest.BaseDocument = baseDocument
+		err = r.client.ValidateUpdateTemplate(ctx, state.ID.ValueString(), updateRequest)
+		localChecks = func() diag.Diagnostics {
+			return validateTemplateRequestLocally(updateRequest.BuiltInAttributes, updateRequest.CustomAttributes, updateRequest.TemplateAttributes)
+		}
+	}
+
+	if err == nil {
+		return diags
+	}
+
+	if apiError, ok := api.ConvertAPIError(err); ok {
+		diags.AddError("Template validation failed", formatTemplateValidationError(apiError))
+		return diags
+	}
+
+	// The endpoint is not available (older BioT version / transport error), only local checks can be done.
+	if !errors.Is(err, api.SpecificErrorCodes.ValidationUnavailable) {
+		diags.AddWarning(
+			"Template validation API is unreachable",
+			fmt.Sprintf("Falling back to local checks only: %s", err),
+		)
+	}
+	tflog.Info(ctx, "Validating template locally")
+	diags.Append(localChecks()...)
+
+	return diags
+}
+
+// Happens when configured values depend on other resources.
+func skippedTemplateValidation() diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.AddWarning(
+		"Skipping plan-time template validation",
+		"The template contains values that are only known after apply, it will be validated by BioT on apply.",
+	)
+	return diags
+}
+
+func formatTemplateValidationError(apiError api.APIError) string {
+	message := apiError.Error()
+
+	if len(apiError.Details.Attributes) == 0 {
+		return message
+	}
+
+	var attributeNames []string
+	for _, attr := range apiError.Details.Attributes {
+		attributeNames = append(attributeNames, attr.Name)
+	}
+
+	return fmt.Sprintf("%s\nAffected attributes: %s", message, strings.Join(attributeNames, ", "))
+}
+
+// Copies the provider-only settings (that are not returned by BioT) to a model mapped from an API response.
+func copyResourceOptions(source TerraformTemplate, target *TerraformTemplate) {
+	target.ValidateOnPlan = source.ValidateOnPlan
+	target.DeletionProtection = source.DeletionProtection
+	target.ForceDelete = source.ForceDelete
+	target.AdoptExisting = source.AdoptExisting
+	target.FailOnDrift = source.FailOnDrift
+	target.WaitForConsistency = source.WaitForConsistency
+	target.WaitForAnalyticsDbSync = source.WaitForAnalyticsDbSync
+	target.Timeouts = source.Timeouts
+	target.BuiltinAttributesManagement = source.BuiltinAttributesManagement
+	copyAttributeOptions(source, target)
+}
+
+// TF_FORCE_UPDATE=true applies changes that BioT rejects because they delete observation data.
+func forceUpdateFromEnv() (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	forceUpdateString := os.Getenv("TF_FORCE_UPDATE")
+	if forceUpdateString == "" {
+		return false, diags
+	}
+
+	forceUpdate, err := strconv.ParseBool(forceUpdateString)
+	if err != nil {
+		diags.AddError(
+			"Invalid TF_FORCE_UPDATE value",
+			fmt.Sprintf("Value [%q] is not a valid boolean (expected: true / false)", forceUpdateString),
+		)
+	}
+	return forceUpdate, diags
+}
+
+func formatCustomAttributeInUseError(apiError api.APIError, diags *diag.Diagnostics) {
 	// Extract attribute names from the details
 	var attributeNames []string
 	for _, attr := range apiError.Details.Attributes {
@@ -400,46 +906,5 @@ If you choose to apply these changes ALL observation data will be deleted (inclu
 
 TF_FORCE_UPDATE=true terraform apply`, attributeList)
 
-	resp.Diagnostics.AddError("DESTRUCTIVE CHANGE WARNING", warningMessage)
-}
-
-// Import state Works with entity-type:template-name (instead of ID)
-func (r *BiotTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ":")
-	if len(idParts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid import ID format",
-			`Expected format: "entity-type:template-name" (e.g., "caregiver:doctor")`,
-		)
-		return
-	}
-
-	entityType := idParts[0]
-	templateName := idParts[1]
-	tflog.Debug(ctx, "Starting template import", map[string]interface{}{
-		"entity_type":   entityType,
-		"template_name": templateName,
-	})
-
-	templateResponse, err := r.client.GetTemplateByTypeAndName(ctx, entityType, templateName)
-
-	if err != nil {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Failed to import template [entityType: %q and name: %q]", entityType, templateName),
-			err.Error(),
-		)
-		return
-	}
-	tflog.Debug(ctx, "Successfully retrieved template for import", map[string]interface{}{
-		"template_name": templateResponse.Name,
-		"template_id":   templateResponse.ID,
-	})
-
-	tfModel := mapTemplateResponseToTerrformModel(ctx, templateResponse)
-
-	diags := resp.State.Set(ctx, tfModel)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	diags.AddError("DESTRUCTIVE CHANGE WARNING", warningMessage)
 }
@@ -0,0 +1,472 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	biotvalidators "biot.com/terraform-provider-biot-gen2/internal/resources/biot_validators"
+)
+
+func NewTemplateAttributeResource() resource.Resource {
+	return &BiotTemplateAttributeResource{}
+}
+
+// Manages a single attribute of a template that is not managed by Terraform (or by another configuration), e.g. a
+// custom attribute on the platform owned caregiver template.
+// The attribute is written with read-modify-write: the template is read, only this attribute is changed, and the
+// whole template is sent back. Other attributes and fields of the template are kept as they are.
+type BiotTemplateAttributeResource struct {
+	client *api.APIClient
+}
+
+// The attribute collection of each kind.
+var templateAttributeKinds = map[string]string{
+	"builtin":  "builtin_attributes",
+	"custom":   "custom_attributes",
+	"template": "template_attributes",
+}
+
+// The fields of every kind of attribute, the fields that do not match the kind are rejected by ValidateConfig.
+type TerraformTemplateAttributeResource struct {
+	TemplateID    types.String `tfsdk:"template_id"`
+	Kind          types.String `tfsdk:"kind"`
+	AttributeName types.String `tfsdk:"name"`
+
+	BaseTerraformAttribute
+
+	AnalyticsDbConfiguration *TerraformAnalyticsDbConfiguration `tfsdk:"analytics_db_configuration"`
+	Value                    types.String                       `tfsdk:"value_json"`
+	OrganizationSelection    *TerraformOrganizationSelection    `tfsdk:"organization_selection"`
+}
+
+var _ resource.ResourceWithImportState = &BiotTemplateAttributeResource{}
+var _ resource.ResourceWithValidateConfig = &BiotTemplateAttributeResource{}
+
+func (r *BiotTemplateAttributeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_template_attribute"
+}
+
+func (r *BiotTemplateAttributeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotTemplateAttributeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := maps.Clone(customAttributeSchema())
+	templateAttributes := templateAttributeSchema()
+	attributes["value_json"] = templateAttributes["value_json"]
+	attributes["organization_selection"] = templateAttributes["organization_selection"]
+
+	attributes["template_id"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The ID of the template the attribute belongs to.",
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["kind"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: fmt.Sprintf("The kind of the attribute. One of: %s. A builtin attribute is defined by BioT, it is only configured (and left on the template on destroy).", biotvalidators.FormatMarkdownValues(sortedTemplateAttributeKinds())),
+		Validators: []validator.String{
+			biotvalidators.OneOfWithSuggestion(sortedTemplateAttributeKinds()),
+		},
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+	attributes["name"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The name of the attribute. Changing it renames the attribute in place (it keeps its ID and data).",
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A single attribute of a template that is not managed by a `biot_template` resource (e.g. a platform owned template). " +
+			"Only this attribute is changed, the rest of the template is kept as it is. Do not use it on a template that is managed by `biot_template`.",
+		Attributes: attributes,
+	}
+}
+
+func sortedTemplateAttributeKinds() []string {
+	return []string{"builtin", "custom", "template"}
+}
+
+func (r *BiotTemplateAttributeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config TerraformTemplateAttributeResource
+	if req.Config.Get(ctx, &config).HasError() || config.Kind.IsUnknown() || config.AttributeName.IsUnknown() {
+		// Values that are only known after apply, validated by BioT on apply.
+		return
+	}
+
+	kind := config.Kind.ValueString()
+	collection := templateAttributeKinds[kind]
+
+	if kind == "template" {
+		if config.AnalyticsDbConfiguration != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("analytics_db_configuration"), "Unsupported analytics_db_configuration", "analytics_db_configuration is not supported for template attributes")
+		}
+		if !config.Category.IsNull() && !config.Category.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(path.Root("category"), "Unsupported category", "The category of a template attribute is assigned by BioT")
+		}
+	} else {
+		if !config.Value.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("value_json"), "Unsupported value_json", "value_json is supported only for template attributes")
+		}
+		if config.OrganizationSelection != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("organization_selection"), "Unsupported organization_selection", "organization_selection is supported only for template attributes")
+		}
+	}
+
+	attribute := config.BaseTerraformAttribute
+	attribute.Name = config.AttributeName
+	resp.Diagnostics.Append(checkPreviousNames(collection, config.AttributeName.ValueString(), attribute.PreviousNames, map[string]string{}, path.Empty())...)
+	// The entity type is not configured, it is the one of the template: the category is checked on apply
+	// (checkTemplateAttributeCategory), when the template is read.
+	resp.Diagnostics.Append(validateAttributeConfig(ctx, path.Empty(), attribute, types.StringNull())...)
+}
+
+func (r *BiotTemplateAttributeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformTemplateAttributeResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.client.GetTemplate(ctx, state.TemplateID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read template: %s", err))
+		return
+	}
+
+	// Matched by ID first, so a rename outside Terraform shows as a change of name.
+	name, found := findTemplateAttributeName(mapTemplateResponseToTerrformModel(ctx, current), state.Kind.ValueString(), state.ID.ValueString())
+	if !found {
+		name = state.AttributeName.ValueString()
+	}
+
+	attribute, found := templateAttributeResourceFrom(ctx, current, state, name)
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, attribute)...)
+}
+
+func (r *BiotTemplateAttributeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformTemplateAttributeResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resolveSingleAttributeReferences(ctx, r.client, path.Empty(), &plan.BaseTerraformAttribute)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, diags, _ := r.updateTemplate(ctx, plan.TemplateID.ValueString(), func(template *TerraformTemplate) diag.Diagnostics {
+		var diags diag.Diagnostics
+		kind := plan.Kind.ValueString()
+		name := plan.AttributeName.ValueString()
+		attributes := baseAttributesByCollection(*template)[templateAttributeKinds[kind]]
+
+		if existing, exists := attributes[name]; exists {
+			// Builtin attributes are defined by BioT, the existing one is configured.
+			if kind != "builtin" {
+				diags.AddAttributeError(path.Root("name"), "Attribute already exists", fmt.Sprintf(
+					"The template already has a %s attribute named [%s]. Import it to manage it with this resource.", kind, name,
+				))
+				return diags
+			}
+			plan.ID = existing.ID
+		}
+
+		// An existing attribute named as one of previous_names is renamed.
+		for _, previousName := range plan.PreviousNames {
+			if existing, exists := attributes[previousName.ValueString()]; exists && plan.ID.IsUnknown() {
+				plan.ID = existing.ID
+				removeTemplateAttribute(template, kind, previousName.ValueString())
+			}
+		}
+
+		diags.Append(checkTemplateAttributeCategory(*template, plan)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		setTemplateAttribute(template, plan)
+		return diags
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attribute, found := templateAttributeResourceFrom(ctx, response, plan, plan.AttributeName.ValueString())
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Attribute [%s] was not found in the template after it was added", plan.AttributeName.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, attribute)...)
+}
+
+func (r *BiotTemplateAttributeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TerraformTemplateAttributeResource
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resolveSingleAttributeReferences(ctx, r.client, path.Empty(), &plan.BaseTerraformAttribute)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, diags, _ := r.updateTemplate(ctx, plan.TemplateID.ValueString(), func(template *TerraformTemplate) diag.Diagnostics {
+		// The attribute keeps its ID, so a new name renames it.
+		if diags := checkTemplateAttributeCategory(*template, plan); diags.HasError() {
+			return diags
+		}
+
+		if name, found := findTemplateAttributeName(*template, plan.Kind.ValueString(), state.ID.ValueString()); found {
+			removeTemplateAttribute(template, plan.Kind.ValueString(), name)
+		}
+		setTemplateAttribute(template, plan)
+		return nil
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attribute, found := templateAttributeResourceFrom(ctx, response, plan, plan.AttributeName.ValueString())
+	if !found {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Attribute [%s] was not found in the template after it was updated", plan.AttributeName.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, attribute)...)
+}
+
+func (r *BiotTemplateAttributeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformTemplateAttributeResource
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Builtin attributes are defined by BioT, they stay on the template.
+	if state.Kind.ValueString() == "builtin" {
+		return
+	}
+
+	_, diags, err := r.updateTemplate(ctx, state.TemplateID.ValueString(), func(template *TerraformTemplate) diag.Diagnostics {
+		if name, found := findTemplateAttributeName(*template, state.Kind.ValueString(), state.ID.ValueString()); found {
+			removeTemplateAttribute(template, state.Kind.ValueString(), name)
+		}
+		return nil
+	})
+	if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+		// Deleted with the template.
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+}
+
+// Import ID format: "template_id/kind/name", e.g. "3fa85f64-5717-4562-b3fc-2c963f66afa6/custom/weight".
+func (r *BiotTemplateAttributeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" || templateAttributeKinds[parts[1]] == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf(
+			"Expected \"template_id/kind/name\" with kind one of %s (e.g. \"3fa85f64-5717-4562-b3fc-2c963f66afa6/custom/weight\"), got [%s]",
+			strings.Join(sortedTemplateAttributeKinds(), ", "), req.ID,
+		))
+		return
+	}
+
+	current, err := r.client.GetTemplate(ctx, parts[0])
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read template [%s]: %s", parts[0], err))
+		return
+	}
+
+	source := TerraformTemplateAttributeResource{
+		TemplateID:    types.StringValue(parts[0]),
+		Kind:          types.StringValue(parts[1]),
+		AttributeName: types.StringValue(parts[2]),
+	}
+	attribute, found := templateAttributeResourceFrom(ctx, current, source, parts[2])
+	if !found {
+		resp.Diagnostics.AddError("Attribute not found", fmt.Sprintf("Template [%s] does not have a %s attribute named [%s]", current.Name, parts[1], parts[2]))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, attribute)...)
+}
+
+// Reads the template, applies edit to it and sends the whole template back, while holding the template lock.
+// The returned error is the one of reading the template (e.g. api.SpecificErrorCodes.NotFound when it does not exist),
+// it is also reported in the diagnostics.
+func (r *BiotTemplateAttributeResource) updateTemplate(ctx context.Context, templateID string, edit func(template *TerraformTemplate) diag.Diagnostics) (api.TemplateResponse, diag.Diagnostics, error) {
+	var diags diag.Diagnostics
+
+	defer lockTemplate(templateID)()
+
+	current, err := r.client.GetTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			diags.AddAttributeError(path.Root("template_id"), "Template not found", fmt.Sprintf("Template [%s] does not exist", templateID))
+			return api.TemplateResponse{}, diags, err
+		}
+		diags.AddError("API Error", fmt.Sprintf("Failed to read template: %s", err))
+		return api.TemplateResponse{}, diags, err
+	}
+
+	forceUpdate, forceDiags := forceUpdateFromEnv()
+	diags.Append(forceDiags...)
+	if diags.HasError() {
+		return api.TemplateResponse{}, diags, nil
+	}
+
+	template := mapTemplateResponseToTerrformModel(ctx, current)
+	diags.Append(edit(&template)...)
+	if diags.HasError() {
+		return api.TemplateResponse{}, diags, nil
+	}
+
+	updateRequest := MapTerraformTemplateToUpdateRequest(ctx, template)
+	updateRequest.BaseDocument = current.Raw
+
+	response, err := r.client.UpdateTemplate(ctx, templateID, updateRequest, forceUpdate)
+	if err != nil {
+		if apiError, ok := api.ConvertAPIError(err); ok && apiError.Code == "CUSTOM_ATTRIBUTE_IN_USE" {
+			formatCustomAttributeInUseError(apiError, &diags)
+		} else {
+			diags.AddError("API Error", fmt.Sprintf("Failed to update template [%s]: %s", current.Name, err))
+		}
+		return api.TemplateResponse{}, diags, nil
+	}
+
+	return response, diags, nil
+}
+
+// Checks that the category of the attribute is supported by the entity type of the template it is added to.
+func checkTemplateAttributeCategory(template TerraformTemplate, attribute TerraformTemplateAttributeResource) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, problem := range checkCategoryConfig(attribute.BaseTerraformAttribute, template.EntityTypeName) {
+		problem.addTo(&diags, path.Empty())
+	}
+	return diags
+}
+
+func setTemplateAttribute(template *TerraformTemplate, attribute TerraformTemplateAttributeResource) {
+	name := attribute.AttributeName.ValueString()
+	base := attribute.BaseTerraformAttribute
+	base.Name = attribute.AttributeName
+
+	switch attribute.Kind.ValueString() {
+	case "builtin":
+		template.BuiltInAttributes[name] = TerraformBuiltinAttribute{BaseTerraformAttribute: base, AnalyticsDbConfiguration: attribute.AnalyticsDbConfiguration}
+	case "custom":
+		template.CustomAttributes[name] = TerraformCustomAttribute{BaseTerraformAttribute: base, AnalyticsDbConfiguration: attribute.AnalyticsDbConfiguration}
+	case "template":
+		template.TemplateAttributes[name] = TerraformTemplateAttribute{BaseTerraformAttribute: base, Value: attribute.Value, OrganizationSelection: attribute.OrganizationSelection}
+	}
+}
+
+func removeTemplateAttribute(template *TerraformTemplate, kind string, name string) {
+	switch kind {
+	case "builtin":
+		delete(template.BuiltInAttributes, name)
+	case "custom":
+		delete(template.CustomAttributes, name)
+	case "template":
+		delete(template.TemplateAttributes, name)
+	}
+}
+
+func findTemplateAttributeName(template TerraformTemplate, kind string, id string) (string, bool) {
+	name, _, found := findAttributeByID(baseAttributesByCollection(template)[templateAttributeKinds[kind]], id)
+	return name, found
+}
+
+// Maps the attribute of the template response to the resource model, with the configuration-only fields of source.
+func templateAttributeResourceFrom(ctx context.Context, response api.TemplateResponse, source TerraformTemplateAttributeResource, name string) (TerraformTemplateAttributeResource, bool) {
+	kind := source.Kind.ValueString()
+	template := mapTemplateResponseToTerrformModel(ctx, response)
+
+	sourceTemplate := TerraformTemplate{TerraformTemplateDefinition: TerraformTemplateDefinition{
+		BuiltInAttributes:  map[string]TerraformBuiltinAttribute{},
+		CustomAttributes:   map[string]TerraformCustomAttribute{},
+		TemplateAttributes: map[string]TerraformTemplateAttribute{},
+	}}
+	renamed := source
+	renamed.AttributeName = types.StringValue(name)
+	setTemplateAttribute(&sourceTemplate, renamed)
+	copyAttributeOptions(sourceTemplate, &template)
+
+	result := TerraformTemplateAttributeResource{
+		TemplateID:    source.TemplateID,
+		Kind:          source.Kind,
+		AttributeName: types.StringValue(name),
+		Value:         types.StringNull(),
+	}
+
+	switch kind {
+	case "builtin":
+		attribute, ok := template.BuiltInAttributes[name]
+		if !ok {
+			return result, false
+		}
+		result.BaseTerraformAttribute = attribute.BaseTerraformAttribute
+		result.AnalyticsDbConfiguration = attribute.AnalyticsDbConfiguration
+	case "custom":
+		attribute, ok := template.CustomAttributes[name]
+		if !ok {
+			return result, false
+		}
+		result.BaseTerraformAttribute = attribute.BaseTerraformAttribute
+		result.AnalyticsDbConfiguration = attribute.AnalyticsDbConfiguration
+	case "template":
+		attribute, ok := template.TemplateAttributes[name]
+		if !ok {
+			return result, false
+		}
+		result.BaseTerraformAttribute = attribute.BaseTerraformAttribute
+		result.Value = attribute.Value
+		result.OrganizationSelection = attribute.OrganizationSelection
+	default:
+		return result, false
+	}
+
+	return result, true
+}
@@ -0,0 +1,450 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	biotplanmodifiers "biot.com/terraform-provider-biot-gen2/internal/resources/biot_plan_modifiers"
+)
+
+func NewTemplateJSONResource() resource.Resource {
+	return &BiotTemplateJSONResource{}
+}
+
+// A template managed as a raw JSON document, for template features that biot_template does not model yet, or for
+// templates exported from the console. The document is sent as is (in the request form), through the same create,
+// update (including TF_FORCE_UPDATE) and delete paths as biot_template.
+type BiotTemplateJSONResource struct {
+	client *api.APIClient
+}
+
+type TerraformTemplateJSON struct {
+	ID                 types.String `tfsdk:"id"`
+	TemplateJSON       types.String `tfsdk:"template_json"`
+	Name               types.String `tfsdk:"name"`
+	EntityType         types.String `tfsdk:"entity_type"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	ForceDelete        types.Bool   `tfsdk:"force_delete"`
+}
+
+var _ resource.ResourceWithImportState = &BiotTemplateJSONResource{}
+var _ resource.ResourceWithValidateConfig = &BiotTemplateJSONResource{}
+var _ resource.ResourceWithModifyPlan = &BiotTemplateJSONResource{}
+
+func (r *BiotTemplateJSONResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_template_json"
+}
+
+func (r *BiotTemplateJSONResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotTemplateJSONResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A template managed as a raw JSON document, for template features that `biot_template` does not support yet, " +
+			"or for templates exported from the console. Prefer `biot_template` when it supports the template.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the template.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"template_json": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					biotplanmodifiers.JsonNormalizePlanModifier{},
+				},
+				MarkdownDescription: "The template document, e.g. `jsonencode({...})` or `file(\"template.json\")`. A document as returned by the API " +
+					"(or exported from the console) is accepted: server-computed fields (`id`, `creationTime`, `validationMetadata`, the IDs of " +
+					"attributes and selectable values, ...) are ignored when it is compared with the template in BioT, and so are formatting and " +
+					"fields the document does not set. A top-level field removed from the document is set to null in BioT. Changing the entity type replaces the template.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the template, from the document.",
+			},
+			"entity_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The entity type of the template, from the document.",
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When true, the template can not be deleted. See `deletion_protection` of `biot_template`.",
+			},
+			"force_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Set to true (and apply) to delete the template even when entities use it. See `force_delete` of `biot_template`.",
+			},
+		},
+	}
+}
+
+func (r *BiotTemplateJSONResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var templateJSON types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("template_json"), &templateJSON)...)
+	if resp.Diagnostics.HasError() || templateJSON.IsNull() || templateJSON.IsUnknown() {
+		return
+	}
+
+	document, err := templateRequestDocument(templateJSON.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("template_json"), "Invalid template_json", err.Error())
+		return
+	}
+
+	for _, field := range []string{"name", "entityType"} {
+		if value, _ := document[field].(string); value == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("template_json"), "Invalid template_json", fmt.Sprintf("The template document must set %q", field))
+		}
+	}
+}
+
+func (r *BiotTemplateJSONResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan TerraformTemplateJSON
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.TemplateJSON.IsUnknown() {
+		return
+	}
+
+	document, err := templateRequestDocument(plan.TemplateJSON.ValueString())
+	if err != nil {
+		// Reported by ValidateConfig.
+		return
+	}
+	name, _ := document["name"].(string)
+	entityType, _ := document["entityType"].(string)
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), types.StringValue(name))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entity_type"), types.StringValue(entityType))...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state TerraformTemplateJSON
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if !resp.Diagnostics.HasError() && state.EntityType.ValueString() != entityType {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("template_json"))
+	}
+}
+
+func (r *BiotTemplateJSONResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformTemplateJSON
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetTemplate(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read template: %s", err))
+		return
+	}
+
+	templateJSON, err := currentTemplateJSON(state.TemplateJSON, response)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid template document", fmt.Sprintf("Failed to compare template [%s] with template_json: %s", response.Name, err))
+		return
+	}
+
+	state.TemplateJSON = templateJSON
+	state.Name = types.StringValue(response.Name)
+	state.EntityType = types.StringValue(response.EntityTypeName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	resp.Diagnostics.Append(saveTemplateDocument(ctx, resp.Private, response)...)
+}
+
+func (r *BiotTemplateJSONResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformTemplateJSON
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := marshalTemplateRequestDocument(plan.TemplateJSON.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("template_json"), "Invalid template_json", err.Error())
+		return
+	}
+
+	response, err := r.client.CreateTemplate(ctx, api.CreateTemplateRequest{Document: document})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to create template: %s", err))
+		return
+	}
+
+	plan.ID = types.StringValue(response.ID)
+	plan.Name = types.StringValue(response.Name)
+	plan.EntityType = types.StringValue(response.EntityTypeName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(saveTemplateDocument(ctx, resp.Private, response)...)
+}
+
+func (r *BiotTemplateJSONResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TerraformTemplateJSON
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defer lockTemplate(state.ID.ValueString())()
+
+	forceUpdate, diags := forceUpdateFromEnv()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fields that the document does not set keep their values in BioT.
+	baseDocument, diags := loadTemplateDocument(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := marshalTemplateUpdateDocument(plan.TemplateJSON.ValueString(), state.TemplateJSON.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("template_json"), "Invalid template_json", err.Error())
+		return
+	}
+
+	updateRequest := api.UpdateTemplateRequest{Document: document, BaseDocument: baseDocument}
+	response, err := r.client.UpdateTemplate(ctx, state.ID.ValueString(), updateRequest, forceUpdate)
+	if err != nil {
+		if apiError, ok := api.ConvertAPIError(err); ok && apiError.Code == "CUSTOM_ATTRIBUTE_IN_USE" {
+			formatCustomAttributeInUseError(apiError, &resp.Diagnostics)
+		} else {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to update template: %s", err))
+		}
+		return
+	}
+
+	plan.ID = types.StringValue(response.ID)
+	plan.Name = types.StringValue(response.Name)
+	plan.EntityType = types.StringValue(response.EntityTypeName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(saveTemplateDocument(ctx, resp.Private, response)...)
+}
+
+func (r *BiotTemplateJSONResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformTemplateJSON
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template := TerraformTemplate{DeletionProtection: state.DeletionProtection, ForceDelete: state.ForceDelete}
+	template.ID = state.ID
+	template.Name = state.Name
+	template.EntityTypeName = state.EntityType
+
+	resp.Diagnostics.Append(checkTemplateDeletionAllowed(ctx, r.client, template)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteTemplate(ctx, state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to delete template: %s", err))
+	}
+}
+
+// Imported by template ID, template_json is set to the current template document.
+func (r *BiotTemplateJSONResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Parses template_json to the request form of the document.
+func templateRequestDocument(templateJSON string) (map[string]interface{}, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal([]byte(templateJSON), &document); err != nil {
+		return nil, fmt.Errorf("template_json is not a JSON object: %w", err)
+	}
+
+	api.ToTemplateRequestDocument(document)
+	return document, nil
+}
+
+func marshalTemplateRequestDocument(templateJSON string) (json.RawMessage, error) {
+	document, err := templateRequestDocument(templateJSON)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// The update is merged over the current template document, so a top-level field that was removed from template_json
+// (it is in previousJSON, the value in state) is sent as null, otherwise BioT would keep its value.
+// The entity type of a template can not be updated, it is not sent on update.
+func marshalTemplateUpdateDocument(templateJSON string, previousJSON string) (json.RawMessage, error) {
+	document, err := templateRequestDocument(templateJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous, err := templateRequestDocument(previousJSON); err == nil {
+		for field := range previous {
+			if _, exists := document[field]; !exists {
+				document[field] = nil
+			}
+		}
+	}
+
+	delete(document, "entityType")
+	return json.Marshal(document)
+}
+
+// Returns the value of template_json that matches the template in BioT: the configured value when they match,
+// otherwise the fields of the template that the configured value sets, so the plan shows what changed.
+// With no configured value (import), the whole template document.
+func currentTemplateJSON(configured types.String, response api.TemplateResponse) (types.String, error) {
+	current, err := comparableTemplateDocument(string(response.Raw))
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	if configured.IsNull() || configured.IsUnknown() {
+		return marshalTemplateJSON(current)
+	}
+
+	configuredDocument, err := comparableTemplateDocument(configured.ValueString())
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	projected := projectTemplateDocument(configuredDocument, current)
+	if jsonEqual(configuredDocument, projected) {
+		return configured, nil
+	}
+	return marshalTemplateJSON(projected)
+}
+
+// The form of a template document that is compared: normalized (as by JsonNormalizePlanModifier), in the request
+// form, without the IDs BioT assigns to attributes and selectable values, and with them ordered by name.
+func comparableTemplateDocument(templateJSON string) (interface{}, error) {
+	normalized, err := biotplanmodifiers.NormalizeJSON(templateJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := templateRequestDocument(normalized)
+	if err != nil {
+		return nil, err
+	}
+	return withoutAssignedIDs(document), nil
+}
+
+func withoutAssignedIDs(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, field := range typed {
+			typed[key] = withoutAssignedIDs(field)
+		}
+		return typed
+
+	case []interface{}:
+		if _, named := elementsByName(typed); named {
+			for _, element := range typed {
+				delete(element.(map[string]interface{}), "id")
+			}
+			sort.SliceStable(typed, func(i, j int) bool {
+				return typed[i].(map[string]interface{})["name"].(string) < typed[j].(map[string]interface{})["name"].(string)
+			})
+		}
+		for i, element := range typed {
+			typed[i] = withoutAssignedIDs(element)
+		}
+		return typed
+
+	default:
+		return value
+	}
+}
+
+// Keeps the fields of current that are set in configured, so fields BioT fills in (defaults, settings made only in
+// the console) are not reported as changes. All named elements (attributes, selectable values) of current are kept,
+// so elements added outside Terraform are reported.
+func projectTemplateDocument(configured interface{}, current interface{}) interface{} {
+	switch configuredValue := configured.(type) {
+	case map[string]interface{}:
+		currentObject, ok := current.(map[string]interface{})
+		if !ok {
+			return current
+		}
+
+		projected := make(map[string]interface{}, len(configuredValue))
+		for key, value := range configuredValue {
+			if currentValue, exists := currentObject[key]; exists {
+				projected[key] = projectTemplateDocument(value, currentValue)
+			}
+		}
+		return projected
+
+	case []interface{}:
+		configuredByName, configuredNamed := elementsByName(configuredValue)
+		currentElements, currentIsArray := current.([]interface{})
+		if !configuredNamed || !currentIsArray {
+			return current
+		}
+
+		projected := make([]interface{}, 0, len(currentElements))
+		for _, element := range currentElements {
+			object, _ := element.(map[string]interface{})
+			name, _ := object["name"].(string)
+			if configuredElement, exists := configuredByName[name]; exists && object != nil {
+				element = projectTemplateDocument(configuredElement, element)
+			}
+			projected = append(projected, element)
+		}
+		return projected
+
+	default:
+		return current
+	}
+}
+
+func jsonEqual(a interface{}, b interface{}) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
+
+func marshalTemplateJSON(document interface{}) (types.String, error) {
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(string(encoded)), nil
+}
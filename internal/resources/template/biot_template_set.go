@@ -0,0 +1,744 @@
+package template
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	biotvalidators "biot.com/terraform-provider-biot-gen2/internal/resources/biot_validators"
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+func NewTemplateSetResource() resource.Resource {
+	return &BiotTemplateSetResource{}
+}
+
+// Manages several templates that reference each other (e.g. a patient template that references a device template,
+// and the device template that references the patient), which would be a dependency cycle between biot_template
+// resources.
+// The templates are created in two phases: first without the attributes that reference other templates of the
+// set, then those attributes are added once every template of the set exists. On destroy, the references are
+// removed first and the templates are deleted in reverse order.
+type BiotTemplateSetResource struct {
+	client *api.APIClient
+}
+
+type TerraformTemplateSet struct {
+	ID        types.String                           `tfsdk:"id"`
+	Templates map[string]TerraformTemplateDefinition `tfsdk:"templates"`
+
+	// Provider-only settings, never sent to BioT. Apply to every template of the set.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDelete        types.Bool `tfsdk:"force_delete"`
+}
+
+var _ resource.ResourceWithModifyPlan = &BiotTemplateSetResource{}
+var _ resource.ResourceWithValidateConfig = &BiotTemplateSetResource{}
+
+func (r *BiotTemplateSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_template_set"
+}
+
+func (r *BiotTemplateSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotTemplateSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A set of templates that reference each other. The templates are created without their cross references, " +
+			"which are added once every template of the set exists. Reference the other templates of the set by name " +
+			"(`link_configuration.template_name`, `reference_configuration.valid_template_names`).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the template set (generated by the provider).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"templates": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "The templates of the set, keyed by an arbitrary key. Templates are created in key order and deleted in reverse key order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: templateSetMemberSchema(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "When true, no template of the set can be deleted (by destroying the set, removing the template from `templates`, " +
+					"or changing what requires it to be created again). When false or not set, a template is still protected while entities use it, see `force_delete`.",
+			},
+			"force_delete": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Before templates of the set are deleted, the entities that use them are counted, and the delete is refused when there are any, " +
+					"since deleting a template also deletes its entities. Set to true to delete the templates anyway. Does not override `deletion_protection = true`. " +
+					"Also required when attributes of the other templates reference a template that is deleted: those attributes are removed before the delete " +
+					"and added again, which deletes their values.",
+			},
+		},
+	}
+}
+
+func templateSetMemberSchema() map[string]schema.Attribute {
+	attributes := maps.Clone(templateDefinitionSchema())
+
+	// A single template of the set can not require the replacement of the whole set, it is deleted and created again
+	// on update instead (see ModifyPlan).
+	attributes["entity_type"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: fmt.Sprintf("The entity type of the template. Known values: %s. Changing it deletes the template and creates it again.", biotvalidators.FormatMarkdownValues(entityTypes)),
+		Validators: []validator.String{
+			biotvalidators.KnownValuesWithSuggestion(entityTypes),
+		},
+	}
+	attributes["parent_template_id"] = schema.StringAttribute{
+		Optional:    true,
+		Description: "The ID of the parent template. Changing it deletes the template and creates it again.",
+	}
+
+	return attributes
+}
+
+func (r *BiotTemplateSetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var templates types.Map
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("templates"), &templates)...)
+	if resp.Diagnostics.HasError() || templates.IsNull() || templates.IsUnknown() {
+		return
+	}
+
+	keysByName := map[string]string{}
+	for _, key := range utils.SortedKeys(templates.Elements()) {
+		memberPath := path.Root("templates").AtMapKey(key)
+		resp.Diagnostics.Append(validateTemplateConfigAttributes(ctx, memberPath, req.Config.GetAttribute)...)
+
+		var entityType, name types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, memberPath.AtName("entity_type"), &entityType)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, memberPath.AtName("name"), &name)...)
+		if !isKnownString(entityType) || !isKnownString(name) {
+			continue
+		}
+
+		// Templates of the set are referenced by entity type + name, which must be unique.
+		nameKey := entityType.ValueString() + "/" + name.ValueString()
+		if otherKey, ok := keysByName[nameKey]; ok {
+			resp.Diagnostics.AddAttributeError(memberPath.AtName("name"), "Duplicate template name", fmt.Sprintf(
+				"Templates [%s] and [%s] are both named [%s] with entity type [%s]", otherKey, key, name.ValueString(), entityType.ValueString(),
+			))
+			continue
+		}
+		keysByName[nameKey] = key
+	}
+}
+
+func (r *BiotTemplateSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state TerraformTemplateSet
+	if req.Plan.Get(ctx, &plan).HasError() || req.State.Get(ctx, &state).HasError() {
+		return
+	}
+
+	recreated := false
+	for _, key := range utils.SortedKeys(plan.Templates) {
+		memberPath := path.Root("templates").AtMapKey(key)
+		stateMember, ok := state.Templates[key]
+		if ok && !requiresRecreate(stateMember, plan.Templates[key]) {
+			resp.Diagnostics.Append(checkReadOnlyAttributeChanges(ctx, memberPath, asTemplate(stateMember), knownPlannedAttributes(ctx, req.Plan, memberPath))...)
+		}
+
+		if ok && requiresRecreate(stateMember, plan.Templates[key]) {
+			plan.Templates[key] = withUnknownIDs(plan.Templates[key])
+			recreated = true
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("templates").AtMapKey(key),
+				"Template will be replaced",
+				fmt.Sprintf("entity_type / parent_template_id can not be changed on an existing template, so template [%s] will be deleted and created again. "+
+					"Deleting a template also deletes ALL the entities (and their data) that were created from it.", stateMember.Name.ValueString()),
+			)
+		}
+	}
+
+	changed, diags := planReferencesToDeletedMembers(state, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	if recreated || changed {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+	}
+}
+
+// Before a template of the set is deleted (removed from templates, or created again), the attributes of the other
+// templates that reference it are removed (see deleteMembers), which deletes their values in every entity, and are
+// then added again as new attributes. This is reported as an error unless force_delete is true, and the IDs of those
+// attributes are planned as unknown, so they are created again instead of being updated.
+// Returns whether the plan was changed.
+func planReferencesToDeletedMembers(state TerraformTemplateSet, plan *TerraformTemplateSet) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	deleted := TerraformTemplateSet{Templates: map[string]TerraformTemplateDefinition{}}
+	for key, stateMember := range state.Templates {
+		if planMember, planned := plan.Templates[key]; !planned || requiresRecreate(stateMember, planMember) {
+			deleted.Templates[key] = stateMember
+		}
+	}
+	if len(deleted.Templates) == 0 {
+		return false, diags
+	}
+	isDeletedReference := setReferenceMatcher(deleted)
+
+	changed := false
+	for _, key := range utils.SortedKeys(plan.Templates) {
+		if _, isDeleted := deleted.Templates[key]; isDeleted {
+			continue
+		}
+		stateMember, exists := state.Templates[key]
+		if !exists {
+			continue
+		}
+
+		// Matched by ID, so an attribute that is renamed in the same apply is found too.
+		removedIDs := map[string]bool{}
+		forEachAttribute(TerraformTemplate{}, &TerraformTemplate{TerraformTemplateDefinition: stateMember}, func(_ string, _ string, _ *BaseTerraformAttribute, attribute *BaseTerraformAttribute) {
+			if isKnownString(attribute.ID) && isDeletedReference(*attribute) {
+				removedIDs[attribute.ID.ValueString()] = true
+			}
+		})
+		if len(removedIDs) == 0 {
+			continue
+		}
+
+		member := asTemplate(plan.Templates[key])
+		forEachAttribute(TerraformTemplate{}, &member, func(collection string, name string, _ *BaseTerraformAttribute, attribute *BaseTerraformAttribute) {
+			if !isKnownString(attribute.ID) || !removedIDs[attribute.ID.ValueString()] {
+				return
+			}
+
+			attributePath := path.Root("templates").AtMapKey(key).AtName(collection).AtMapKey(name)
+			summary := "Attribute will be removed and added again"
+			detail := fmt.Sprintf("Attribute [%s] of template [%s] references a template of the set that will be deleted, so it is removed before the delete "+
+				"and added again as a new attribute. Its values are deleted in every entity of template [%s].", name, member.Name.ValueString(), member.Name.ValueString())
+			if plan.ForceDelete.ValueBool() {
+				diags.AddAttributeWarning(attributePath, summary, detail)
+			} else {
+				diags.AddAttributeError(attributePath, summary, detail+" Set force_delete to true to apply anyway.")
+			}
+
+			withUnknownAttributeIDs(attribute)
+			changed = true
+		})
+		plan.Templates[key] = member.TerraformTemplateDefinition
+	}
+
+	return changed, diags
+}
+
+// A template that is created again gets new IDs, so the IDs kept from state (UseStateForUnknown) are planned as
+// unknown, as is the rest of what BioT computes. Otherwise the create request would send the IDs of the deleted
+// template.
+func withUnknownIDs(member TerraformTemplateDefinition) TerraformTemplateDefinition {
+	template := asTemplate(member)
+	template.ID = types.StringUnknown()
+	template.ParentTemplate = types.ObjectUnknown(parentTemplateAttributeTypes)
+
+	forEachAttribute(TerraformTemplate{}, &template, func(_ string, _ string, _ *BaseTerraformAttribute, attribute *BaseTerraformAttribute) {
+		withUnknownAttributeIDs(attribute)
+	})
+
+	return template.TerraformTemplateDefinition
+}
+
+// Plans the IDs of an attribute that is created again as unknown, with the rest of what BioT computes for it.
+func withUnknownAttributeIDs(attribute *BaseTerraformAttribute) {
+	attribute.ID = types.StringUnknown()
+	attribute.ValidationMetadata = types.ObjectUnknown(validationMetadataAttributeTypes)
+	selectableValues := make([]TerraformSelectableValue, len(attribute.SelectableValues))
+	for i, value := range attribute.SelectableValues {
+		value.ID = types.StringUnknown()
+		selectableValues[i] = value
+	}
+	if attribute.SelectableValues != nil {
+		attribute.SelectableValues = selectableValues
+	}
+}
+
+func (r *BiotTemplateSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformTemplateSet
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templates := map[string]TerraformTemplateDefinition{}
+	for _, key := range utils.SortedKeys(state.Templates) {
+		member := asTemplate(state.Templates[key])
+
+		response, err := r.client.GetTemplate(ctx, member.ID.ValueString())
+		if err != nil {
+			if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+				// Removed outside of Terraform, the next plan creates it again.
+				tflog.Warn(ctx, "Template of the template set was not found", map[string]interface{}{
+					"key":         key,
+					"template_id": member.ID.ValueString(),
+				})
+				continue
+			}
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read template [%s]: %s", key, err))
+			return
+		}
+
+		templates[key] = toTemplateSetMember(ctx, response, member)
+	}
+
+	if len(templates) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Templates = templates
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BiotTemplateSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformTemplateSet
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := TerraformTemplateSet{
+		ID:                 types.StringValue(newTemplateSetID()),
+		Templates:          map[string]TerraformTemplateDefinition{},
+		DeletionProtection: plan.DeletionProtection,
+		ForceDelete:        plan.ForceDelete,
+	}
+
+	resp.Diagnostics.Append(r.createAndUpdateMembers(ctx, plan, slices.Sorted(maps.Keys(plan.Templates)), nil, &state)...)
+
+	// Set even on errors, so the templates that were created are tracked (and deleted on destroy).
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BiotTemplateSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, priorState TerraformTemplateSet
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := TerraformTemplateSet{
+		ID:                 priorState.ID,
+		Templates:          maps.Clone(priorState.Templates),
+		DeletionProtection: plan.DeletionProtection,
+		ForceDelete:        plan.ForceDelete,
+	}
+
+	// Unknown planned values (computed by BioT) are not changes.
+	var plannedTemplates, stateTemplates types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("templates"), &plannedTemplates)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("templates"), &stateTemplates)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var toDelete, toCreate, toUpdate []string
+	for _, key := range utils.SortedKeys(priorState.Templates) {
+		planMember, planned := plan.Templates[key]
+		if !planned || requiresRecreate(priorState.Templates[key], planMember) {
+			toDelete = append(toDelete, key)
+		}
+	}
+	for _, key := range utils.SortedKeys(plan.Templates) {
+		stateMember, exists := priorState.Templates[key]
+		switch {
+		case !exists || requiresRecreate(stateMember, plan.Templates[key]):
+			toCreate = append(toCreate, key)
+		case plannedValueChanged(stateTemplates.Elements()[key], plannedTemplates.Elements()[key]):
+			toUpdate = append(toUpdate, key)
+		}
+	}
+
+	// Deleted first, a template that is created again has the same name.
+	// The deletion settings of the plan apply, so force_delete can be set in the same apply that removes a template.
+	strippedKeys, diags := r.deleteMembers(ctx, &state, toDelete, plan)
+	resp.Diagnostics.Append(diags...)
+	if !resp.Diagnostics.HasError() {
+		// The templates whose references to deleted templates were removed get their planned definition back.
+		for _, key := range strippedKeys {
+			if _, planned := plan.Templates[key]; planned && !slices.Contains(toUpdate, key) {
+				toUpdate = append(toUpdate, key)
+			}
+		}
+		resp.Diagnostics.Append(r.createAndUpdateMembers(ctx, plan, toCreate, toUpdate, &state)...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BiotTemplateSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformTemplateSet
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := r.deleteMembers(ctx, &state, slices.Sorted(maps.Keys(state.Templates)), state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Phase 1 creates the templates of toCreate without their references to templates of the set.
+// Phase 2 updates the templates of toUpdate, and the created templates whose references were left out, with their
+// full definition (the references are resolved by name, now that every template exists).
+// state is updated after every successful call, so it reflects what exists in BioT even when a call fails.
+func (r *BiotTemplateSetResource) createAndUpdateMembers(ctx context.Context, plan TerraformTemplateSet, toCreate []string, toUpdate []string, state *TerraformTemplateSet) diag.Diagnostics {
+	var diags diag.Diagnostics
+	isSetReference := setReferenceMatcher(plan, *state)
+
+	for _, key := range toCreate {
+		memberPath := path.Root("templates").AtMapKey(key)
+		member := asTemplate(plan.Templates[key])
+
+		withoutReferences, stripped := withoutSetReferences(member, isSetReference)
+		diags.Append(resolveAttributeReferences(ctx, r.client, memberPath, &withoutReferences)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		response, err := r.client.CreateTemplate(ctx, MapTerraformTemplateToCreateRequest(ctx, withoutReferences))
+		if err != nil {
+			diags.AddAttributeError(memberPath, "API Error", fmt.Sprintf("Failed to create template [%s]: %s", key, err))
+			return diags
+		}
+
+		tflog.Debug(ctx, "Created template of the template set", map[string]interface{}{
+			"key":         key,
+			"template_id": response.ID,
+			"two_phase":   stripped,
+		})
+
+		if stripped {
+			// The full definition is applied in phase 2, until then the state holds what was created.
+			state.Templates[key] = toTemplateSetMember(ctx, response, withoutReferences)
+			toUpdate = append(toUpdate, key)
+		} else {
+			state.Templates[key] = toTemplateSetMember(ctx, response, member)
+		}
+	}
+
+	for _, key := range toUpdate {
+		memberPath := path.Root("templates").AtMapKey(key)
+		member := asTemplate(plan.Templates[key])
+		member.ID = state.Templates[key].ID
+
+		response, updateDiags := r.updateMember(ctx, memberPath, member)
+		diags.Append(updateDiags...)
+		if diags.HasError() {
+			return diags
+		}
+
+		state.Templates[key] = toTemplateSetMember(ctx, response, member)
+	}
+
+	return diags
+}
+
+func (r *BiotTemplateSetResource) updateMember(ctx context.Context, memberPath path.Path, member TerraformTemplate) (api.TemplateResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	diags.Append(resolveAttributeReferences(ctx, r.client, memberPath, &member)...)
+	if diags.HasError() {
+		return api.TemplateResponse{}, diags
+	}
+
+	// The update is merged over the current template document, so fields the provider does not model are kept.
+	current, err := r.client.GetTemplate(ctx, member.ID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(memberPath, "API Error", fmt.Sprintf("Failed to read template before update: %s", err))
+		return api.TemplateResponse{}, diags
+	}
+
+	if isDeclaredBuiltinAttributesManagement(member) {
+		addUndeclaredBuiltinAttributes(ctx, &member, current)
+	}
+
+	updateRequest := MapTerraformTemplateToUpdateRequest(ctx, member)
+	updateRequest.BaseDocument = current.Raw
+
+	response, err := r.client.UpdateTemplate(ctx, member.ID.ValueString(), updateRequest, false)
+	if err != nil {
+		diags.AddAttributeError(memberPath, "API Error", fmt.Sprintf("Failed to update template [%s]: %s", member.Name.ValueString(), err))
+		return api.TemplateResponse{}, diags
+	}
+
+	return response, diags
+}
+
+// Every template is checked first (deletion_protection / force_delete of settings, and the entities that use it), and
+// nothing is deleted when one of them can not be. Then the references to the deleted templates are removed, from the
+// deleted templates and from the other templates of the set, so BioT does not reject deleting a template that is still
+// referenced. Then the templates are deleted in reverse key order.
+// Returns the keys of the templates that are not deleted and whose references were removed.
+func (r *BiotTemplateSetResource) deleteMembers(ctx context.Context, state *TerraformTemplateSet, keys []string, settings TerraformTemplateSet) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(keys) == 0 {
+		return nil, diags
+	}
+
+	for _, key := range keys {
+		member := asTemplate(state.Templates[key])
+		member.DeletionProtection = settings.DeletionProtection
+		member.ForceDelete = settings.ForceDelete
+		for _, d := range checkTemplateDeletionAllowed(ctx, r.client, member) {
+			if d.Severity() == diag.SeverityWarning {
+				diags.AddAttributeWarning(path.Root("templates").AtMapKey(key), d.Summary(), d.Detail())
+			} else {
+				diags.AddAttributeError(path.Root("templates").AtMapKey(key), d.Summary(), d.Detail())
+			}
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	deleted := TerraformTemplateSet{Templates: map[string]TerraformTemplateDefinition{}}
+	for _, key := range keys {
+		deleted.Templates[key] = state.Templates[key]
+	}
+	isSetReference := setReferenceMatcher(*state, TerraformTemplateSet{})
+	isDeletedReference := setReferenceMatcher(deleted)
+
+	var strippedKeys []string
+	for _, key := range utils.SortedKeys(state.Templates) {
+		member := asTemplate(state.Templates[key])
+		isDeletedMember := slices.Contains(keys, key)
+
+		matcher := isDeletedReference
+		if isDeletedMember {
+			matcher = isSetReference
+		}
+		withoutReferences, stripped := withoutSetReferences(member, matcher)
+		if !stripped {
+			continue
+		}
+
+		response, updateDiags := r.updateMember(ctx, path.Root("templates").AtMapKey(key), withoutReferences)
+		if updateDiags.HasError() {
+			// Not fatal, deleting the templates may still succeed.
+			for _, d := range updateDiags {
+				diags.AddWarning("Failed to remove template set references before delete", d.Detail())
+			}
+			continue
+		}
+
+		if !isDeletedMember {
+			state.Templates[key] = toTemplateSetMember(ctx, response, withoutReferences)
+			strippedKeys = append(strippedKeys, key)
+		}
+	}
+
+	for _, key := range slices.Backward(keys) {
+		err := r.client.DeleteTemplate(ctx, state.Templates[key].ID.ValueString())
+		if err != nil && !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			diags.AddAttributeError(path.Root("templates").AtMapKey(key), "API Error", fmt.Sprintf("Failed to delete template [%s]: %s", key, err))
+			return strippedKeys, diags
+		}
+		delete(state.Templates, key)
+	}
+
+	return strippedKeys, diags
+}
+
+// Reports whether a planned value differs from the value in state. Unknown planned values are computed by BioT
+// (e.g. IDs, validation_metadata), they are not changes.
+func plannedValueChanged(stateValue attr.Value, plannedValue attr.Value) bool {
+	if plannedValue == nil || plannedValue.IsUnknown() {
+		return false
+	}
+	if stateValue == nil || stateValue.IsNull() != plannedValue.IsNull() {
+		return true
+	}
+	if plannedValue.IsNull() {
+		return false
+	}
+
+	switch planned := plannedValue.(type) {
+	case basetypes.ObjectValue:
+		stateObject, ok := stateValue.(basetypes.ObjectValue)
+		if !ok {
+			return true
+		}
+		stateAttributes := stateObject.Attributes()
+		for name, value := range planned.Attributes() {
+			if plannedValueChanged(stateAttributes[name], value) {
+				return true
+			}
+		}
+		return false
+
+	case basetypes.MapValue:
+		stateMap, ok := stateValue.(basetypes.MapValue)
+		if !ok || len(stateMap.Elements()) != len(planned.Elements()) {
+			return true
+		}
+		stateElements := stateMap.Elements()
+		for key, value := range planned.Elements() {
+			if plannedValueChanged(stateElements[key], value) {
+				return true
+			}
+		}
+		return false
+
+	case basetypes.ListValue:
+		stateList, ok := stateValue.(basetypes.ListValue)
+		if !ok || len(stateList.Elements()) != len(planned.Elements()) {
+			return true
+		}
+		for i, value := range planned.Elements() {
+			if plannedValueChanged(stateList.Elements()[i], value) {
+				return true
+			}
+		}
+		return false
+
+	case basetypes.SetValue:
+		// Set elements have no identity, every planned element must match an element in state.
+		stateSet, ok := stateValue.(basetypes.SetValue)
+		if !ok || len(stateSet.Elements()) != len(planned.Elements()) {
+			return true
+		}
+		for _, value := range planned.Elements() {
+			if !slices.ContainsFunc(stateSet.Elements(), func(stateElement attr.Value) bool { return !plannedValueChanged(stateElement, value) }) {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return !plannedValue.Equal(stateValue)
+	}
+}
+
+func asTemplate(member TerraformTemplateDefinition) TerraformTemplate {
+	return TerraformTemplate{TerraformTemplateDefinition: member}
+}
+
+func toTemplateSetMember(ctx context.Context, response api.TemplateResponse, source TerraformTemplate) TerraformTemplateDefinition {
+	model := mapTemplateResponseToTerrformModel(ctx, response)
+	copyResourceOptions(source, &model)
+	if isDeclaredBuiltinAttributesManagement(source) {
+		keepDeclaredBuiltinAttributes(&model, source.BuiltInAttributes)
+	}
+	return model.TerraformTemplateDefinition
+}
+
+func requiresRecreate(state TerraformTemplateDefinition, plan TerraformTemplateDefinition) bool {
+	return !state.EntityTypeName.Equal(plan.EntityTypeName) || !state.ParentTemplateID.Equal(plan.ParentTemplateID)
+}
+
+// Returns a function that reports whether an attribute references one of the templates of the given sets, by name
+// (entity type + template name) or by ID.
+func setReferenceMatcher(sets ...TerraformTemplateSet) func(BaseTerraformAttribute) bool {
+	names := map[string]bool{}
+	ids := map[string]bool{}
+	for _, set := range sets {
+		for _, member := range set.Templates {
+			names[member.EntityTypeName.ValueString()+"/"+member.Name.ValueString()] = true
+			if isKnownString(member.ID) {
+				ids[member.ID.ValueString()] = true
+			}
+		}
+	}
+
+	return func(attribute BaseTerraformAttribute) bool {
+		if link := attribute.LinkConfiguration; link != nil {
+			if names[link.EntityTypeName.ValueString()+"/"+link.TemplateName.ValueString()] || ids[link.TemplateID.ValueString()] {
+				return true
+			}
+		}
+
+		if reference := attribute.ReferenceConfiguration; reference != nil {
+			for _, name := range reference.ValidTemplateNames {
+				if names[reference.EntityType.ValueString()+"/"+name.ValueString()] {
+					return true
+				}
+			}
+			for _, id := range utils.ConvertTerraformStringListValue(reference.ValidTemplatesToReference) {
+				if ids[id] {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+}
+
+// Returns a copy of the template without the attributes that reference templates of the set, and whether any
+// attribute was left out.
+func withoutSetReferences(template TerraformTemplate, isSetReference func(BaseTerraformAttribute) bool) (TerraformTemplate, bool) {
+	stripped := false
+	result := template
+
+	result.BuiltInAttributes = map[string]TerraformBuiltinAttribute{}
+	for name, attribute := range template.BuiltInAttributes {
+		if isSetReference(attribute.BaseTerraformAttribute) {
+			stripped = true
+			continue
+		}
+		result.BuiltInAttributes[name] = attribute
+	}
+
+	result.CustomAttributes = map[string]TerraformCustomAttribute{}
+	for name, attribute := range template.CustomAttributes {
+		if isSetReference(attribute.BaseTerraformAttribute) {
+			stripped = true
+			continue
+		}
+		result.CustomAttributes[name] = attribute
+	}
+
+	result.TemplateAttributes = map[string]TerraformTemplateAttribute{}
+	for name, attribute := range template.TemplateAttributes {
+		if isSetReference(attribute.BaseTerraformAttribute) {
+			stripped = true
+			continue
+		}
+		result.TemplateAttributes[name] = attribute
+	}
+
+	return result, stripped
+}
+
+func newTemplateSetID() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
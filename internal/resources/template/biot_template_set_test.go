@@ -0,0 +1,256 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+// Answers the calls made when deleting set members, the other methods of api.BiotSdk are not implemented.
+type testBiotSdk struct {
+	api.BiotSdk
+	entityCounts map[string]int
+	countErr     error
+	updated      []string
+	deleted      []string
+}
+
+func (s *testBiotSdk) LoginAsService(ctx context.Context, serviceID string, serviceSecretKey string) (api.Jwt, error) {
+	return api.Jwt{Token: "token", Expiration: time.Now().Add(time.Hour).Format(time.RFC3339)}, nil
+}
+
+func (s *testBiotSdk) SearchEntities(ctx context.Context, accessToken string, entityType string, searchRequest map[string]interface{}) (api.SearchEntitiesResponse, error) {
+	var response api.SearchEntitiesResponse
+	if s.countErr != nil {
+		return response, s.countErr
+	}
+	filter := searchRequest["filter"].(map[string]interface{})["_template.id"].(map[string]interface{})
+	response.Metadata.Page.TotalResults = s.entityCounts[filter["in"].([]string)[0]]
+	return response, nil
+}
+
+func (s *testBiotSdk) GetTemplate(ctx context.Context, token string, id string) (api.TemplateResponse, error) {
+	return api.TemplateResponse{ID: id}, nil
+}
+
+func (s *testBiotSdk) UpdateTemplate(ctx context.Context, accessToken string, id string, request api.UpdateTemplateRequest, force bool) (api.TemplateResponse, error) {
+	s.updated = append(s.updated, id)
+	return api.TemplateResponse{ID: id}, nil
+}
+
+func (s *testBiotSdk) DeleteTemplate(ctx context.Context, accessToken string, id string) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func newTestClient(t *testing.T, sdk api.BiotSdk) *api.APIClient {
+	t.Helper()
+
+	// The access token is cached on disk.
+	t.Setenv("TF_PLUGIN_CACHE_DIR", t.TempDir())
+	return api.NewAPIClient(sdk, api.NewAuthenticatorService(sdk, "service", "secret"))
+}
+
+func testSetMember(id string, entityType string, name string, parentTemplateID string, attributes map[string]TerraformCustomAttribute) TerraformTemplateDefinition {
+	return TerraformTemplateDefinition{
+		ID:               types.StringValue(id),
+		Name:             types.StringValue(name),
+		EntityTypeName:   types.StringValue(entityType),
+		ParentTemplateID: types.StringValue(parentTemplateID),
+		CustomAttributes: attributes,
+	}
+}
+
+func testLinkAttribute(id string, entityType string, templateName string) TerraformCustomAttribute {
+	attribute := TerraformCustomAttribute{}
+	attribute.ID = types.StringValue(id)
+	attribute.Type = types.StringValue("LINK")
+	attribute.LinkConfiguration = &TerraformLinkConfiguration{
+		EntityTypeName: types.StringValue(entityType),
+		TemplateName:   types.StringValue(templateName),
+	}
+	return attribute
+}
+
+func TestPlanReferencesToDeletedMembers(t *testing.T) {
+	notes := TerraformCustomAttribute{BaseTerraformAttribute: BaseTerraformAttribute{ID: types.StringValue("a2"), Type: types.StringValue("LABEL")}}
+	// A new value every time, the attributes of the plan are changed in place.
+	patient := func() TerraformTemplateDefinition {
+		return testSetMember("p1", "patient", "adult", "", map[string]TerraformCustomAttribute{
+			"monitor": testLinkAttribute("a1", "device", "monitor"),
+			"notes":   notes,
+		})
+	}
+	state := TerraformTemplateSet{Templates: map[string]TerraformTemplateDefinition{
+		"a_device":  testSetMember("d1", "device", "monitor", "", nil),
+		"b_patient": patient(),
+	}}
+
+	tests := []struct {
+		name         string
+		device       *TerraformTemplateDefinition
+		patient      TerraformTemplateDefinition
+		forceDelete  bool
+		wantChanged  bool
+		wantErrors   int
+		wantWarnings int
+	}{
+		{
+			name:    "nothing is deleted",
+			device:  &TerraformTemplateDefinition{},
+			patient: patient(),
+		},
+		{
+			name:        "referenced template is created again",
+			device:      &TerraformTemplateDefinition{ParentTemplateID: types.StringValue("base")},
+			patient:     patient(),
+			wantChanged: true,
+			wantErrors:  1,
+		},
+		{
+			name:         "referenced template is created again with force_delete",
+			device:       &TerraformTemplateDefinition{ParentTemplateID: types.StringValue("base")},
+			patient:      patient(),
+			forceDelete:  true,
+			wantChanged:  true,
+			wantWarnings: 1,
+		},
+		{
+			name: "referencing attribute is removed in the same apply",
+			patient: testSetMember("p1", "patient", "adult", "", map[string]TerraformCustomAttribute{
+				"notes": notes,
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plan := TerraformTemplateSet{
+				Templates:   map[string]TerraformTemplateDefinition{"b_patient": test.patient},
+				ForceDelete: types.BoolValue(test.forceDelete),
+			}
+			if test.device != nil {
+				device := state.Templates["a_device"]
+				if !test.device.ParentTemplateID.IsNull() {
+					device.ParentTemplateID = test.device.ParentTemplateID
+				}
+				plan.Templates["a_device"] = device
+			}
+
+			changed, diags := planReferencesToDeletedMembers(state, &plan)
+			if changed != test.wantChanged {
+				t.Errorf("changed: got %t, want %t", changed, test.wantChanged)
+			}
+			if errors := diags.ErrorsCount(); errors != test.wantErrors {
+				t.Errorf("got %d errors, want %d: %v", errors, test.wantErrors, diags.Errors())
+			}
+			if warnings := diags.WarningsCount(); warnings != test.wantWarnings {
+				t.Errorf("got %d warnings, want %d: %v", warnings, test.wantWarnings, diags.Warnings())
+			}
+
+			patient := plan.Templates["b_patient"]
+			if monitor, ok := patient.CustomAttributes["monitor"]; ok && monitor.ID.IsUnknown() != test.wantChanged {
+				t.Errorf("ID of the referencing attribute: got %s", monitor.ID)
+			}
+			if notes := patient.CustomAttributes["notes"]; notes.ID.ValueString() != "a2" {
+				t.Errorf("ID of an attribute without references: got %s, want a2", notes.ID)
+			}
+		})
+	}
+}
+
+func TestDeleteMembers(t *testing.T) {
+	tests := []struct {
+		name               string
+		entityCounts       map[string]int
+		countErr           error
+		deletionProtection bool
+		forceDelete        bool
+		wantUpdated        []string
+		wantDeleted        []string
+		wantStripped       []string
+		wantErrors         int
+		wantWarnings       int
+	}{
+		{
+			name:         "references are removed before the delete",
+			wantUpdated:  []string{"p1"},
+			wantDeleted:  []string{"d1"},
+			wantStripped: []string{"b_patient"},
+		},
+		{
+			name:         "template with entities is not deleted",
+			entityCounts: map[string]int{"d1": 3},
+			wantErrors:   1,
+		},
+		{
+			name:         "template with entities is deleted with force_delete",
+			entityCounts: map[string]int{"d1": 3},
+			forceDelete:  true,
+			wantUpdated:  []string{"p1"},
+			wantDeleted:  []string{"d1"},
+			wantStripped: []string{"b_patient"},
+		},
+		{
+			name:               "protected template is not deleted",
+			deletionProtection: true,
+			wantErrors:         1,
+		},
+		{
+			name:         "failed entity count is only a warning",
+			countErr:     errors.New("search is not supported"),
+			wantUpdated:  []string{"p1"},
+			wantDeleted:  []string{"d1"},
+			wantStripped: []string{"b_patient"},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sdk := &testBiotSdk{entityCounts: test.entityCounts, countErr: test.countErr}
+			setResource := &BiotTemplateSetResource{client: newTestClient(t, sdk)}
+			state := TerraformTemplateSet{Templates: map[string]TerraformTemplateDefinition{
+				"a_device": testSetMember("d1", "device", "monitor", "", nil),
+				"b_patient": testSetMember("p1", "patient", "adult", "", map[string]TerraformCustomAttribute{
+					"monitor": testLinkAttribute("a1", "device", "monitor"),
+				}),
+			}}
+			settings := TerraformTemplateSet{
+				DeletionProtection: types.BoolValue(test.deletionProtection),
+				ForceDelete:        types.BoolValue(test.forceDelete),
+			}
+
+			stripped, diags := setResource.deleteMembers(context.Background(), &state, []string{"a_device"}, settings)
+			if errors := diags.ErrorsCount(); errors != test.wantErrors {
+				t.Errorf("got %d errors, want %d: %v", errors, test.wantErrors, diags.Errors())
+			}
+			if warnings := diags.WarningsCount(); warnings != test.wantWarnings {
+				t.Errorf("got %d warnings, want %d: %v", warnings, test.wantWarnings, diags.Warnings())
+			}
+			if !slices.Equal(sdk.updated, test.wantUpdated) {
+				t.Errorf("updated templates: got %v, want %v", sdk.updated, test.wantUpdated)
+			}
+			if !slices.Equal(sdk.deleted, test.wantDeleted) {
+				t.Errorf("deleted templates: got %v, want %v", sdk.deleted, test.wantDeleted)
+			}
+			if !slices.Equal(stripped, test.wantStripped) {
+				t.Errorf("stripped members: got %v, want %v", stripped, test.wantStripped)
+			}
+
+			_, deviceInState := state.Templates["a_device"]
+			if deviceInState != (len(test.wantDeleted) == 0) {
+				t.Errorf("deleted member in state: %t", deviceInState)
+			}
+			if _, ok := state.Templates["b_patient"].CustomAttributes["monitor"]; ok != (len(test.wantStripped) == 0) {
+				t.Errorf("reference to the deleted member in state: %t", ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package template
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+const (
+	// Every builtin attribute returned by BioT is tracked in state.
+	builtinAttributesManagementAll = "all"
+	// Only the builtin attributes declared in the configuration are tracked, the rest are left untouched.
+	builtinAttributesManagementDeclared = "declared"
+)
+
+var builtinAttributesManagementModes = []string{builtinAttributesManagementAll, builtinAttributesManagementDeclared}
+
+func isDeclaredBuiltinAttributesManagement(template TerraformTemplate) bool {
+	return template.BuiltinAttributesManagement.ValueString() == builtinAttributesManagementDeclared
+}
+
+// Keeps only the builtin attributes that are declared (by name) in the configuration.
+func keepDeclaredBuiltinAttributes(template *TerraformTemplate, declared map[string]TerraformBuiltinAttribute) {
+	filtered := map[string]TerraformBuiltinAttribute{}
+	for name, attribute := range template.BuiltInAttributes {
+		if _, ok := declared[name]; ok {
+			filtered[name] = attribute
+		}
+	}
+	template.BuiltInAttributes = filtered
+}
+
+// The update request replaces all the builtin attributes, so the ones that are not declared are sent exactly as
+// they are in BioT.
+func addUndeclaredBuiltinAttributes(ctx context.Context, template *TerraformTemplate, current api.TemplateResponse) {
+	builtInAttributes := make(map[string]TerraformBuiltinAttribute, len(current.BuiltInAttributes))
+	for _, attribute := range current.BuiltInAttributes {
+		builtInAttributes[attribute.Name] = mapBuiltinAttributeResponseToTerraformAttribute(ctx, attribute)
+	}
+	for name, attribute := range template.BuiltInAttributes {
+		builtInAttributes[name] = attribute
+	}
+	template.BuiltInAttributes = builtInAttributes
+}
+
+// In "declared" mode, when builtin_attributes are not configured, nothing is declared, so the planned value is an
+// empty map instead of the full list computed by BioT.
+type declaredBuiltinAttributesPlanModifier struct{}
+
+func (m declaredBuiltinAttributesPlanModifier) Description(_ context.Context) string {
+	return "Plans an empty map when builtin_attributes_management is \"declared\" and no builtin attributes are configured."
+}
+
+func (m declaredBuiltinAttributesPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m declaredBuiltinAttributesPlanModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	if !req.ConfigValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var management types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, req.Path.ParentPath().AtName("builtin_attributes_management"), &management)...)
+	if resp.Diagnostics.HasError() || management.ValueString() != builtinAttributesManagementDeclared {
+		return
+	}
+
+	emptyMap, diags := types.MapValue(req.PlanValue.ElementType(ctx), nil)
+	resp.Diagnostics.Append(diags...)
+	if !diags.HasError() {
+		resp.PlanValue = emptyMap
+	}
+}
@@ -0,0 +1,62 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+// Deleting a template also deletes every entity created from it, so a template is deleted only when:
+// deletion_protection is not true, and either no entity uses the template or force_delete is true.
+// The settings are read from state, so they have to be applied before the delete.
+// deletion_protection has no default: the entity count gives templates with entities the protection that a default of
+// true for them would, without counting the entities on every refresh.
+func checkTemplateDeletionAllowed(ctx context.Context, client *api.APIClient, state TerraformTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if state.DeletionProtection.ValueBool() {
+		diags.AddError(
+			"Template is protected from deletion",
+			fmt.Sprintf("Template [%s] has deletion_protection = true. Set deletion_protection = false and apply before deleting it.", state.Name.ValueString()),
+		)
+		return diags
+	}
+
+	if state.ForceDelete.ValueBool() {
+		tflog.Warn(ctx, "Deleting template without checking for entities (force_delete)", map[string]interface{}{
+			"template_id": state.ID.ValueString(),
+		})
+		return diags
+	}
+
+	count, err := client.CountEntities(ctx, state.EntityTypeName.ValueString(), map[string]interface{}{
+		"_template.id": map[string]interface{}{"in": []string{state.ID.ValueString()}},
+	})
+	// The entity search endpoints differ per entity type, a failed count does not block the delete.
+	if err != nil {
+		diags.AddWarning(
+			"Failed to check if the template is in use",
+			fmt.Sprintf("Could not count the %s entities of template [%s], it is deleted without this check: %s\n\n"+
+				"Deleting a template also deletes the entities created from it. Set deletion_protection = true to keep templates from being deleted.",
+				state.EntityTypeName.ValueString(), state.Name.ValueString(), err),
+		)
+		return diags
+	}
+
+	if count > 0 {
+		diags.AddError(
+			"Template is in use",
+			fmt.Sprintf(
+				"Template [%s] is used by %d %s entities. Deleting the template also deletes these entities and their data.\n\n"+
+					"To delete it anyway, set force_delete = true and apply before deleting it.",
+				state.Name.ValueString(), count, state.EntityTypeName.ValueString(),
+			),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,312 @@
+package template
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+// The payload of the template as it was last applied by Terraform (create / update / import), and its hash.
+// It is not updated by Read, so changes made outside Terraform are found on every refresh until the next apply.
+const appliedPayloadPrivateKey = "applied_payload"
+
+// The changes found by the last Read, for fail_on_drift. A refresh only warns when they differ from this report.
+const driftReportPrivateKey = "drift_report"
+
+type appliedPayload struct {
+	SHA256  string          `json:"sha256"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// applied is the template as it is saved in the state, so with builtin_attributes_management = "declared" only the
+// declared builtin attributes are compared.
+func saveAppliedPayload(ctx context.Context, private privateStateSetter, applied TerraformTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	payload, err := json.Marshal(MapTerraformTemplateToUpdateRequest(ctx, applied))
+	if err != nil {
+		diags.AddWarning("Failed to save the applied template", fmt.Sprintf("Changes made outside Terraform will not be reported: %s", err))
+		return diags
+	}
+
+	encoded, err := json.Marshal(appliedPayload{SHA256: payloadHash(payload), Payload: payload})
+	if err != nil {
+		diags.AddWarning("Failed to save the applied template", fmt.Sprintf("Changes made outside Terraform will not be reported: %s", err))
+		return diags
+	}
+
+	diags.Append(private.SetKey(ctx, appliedPayloadPrivateKey, encoded)...)
+	// The applied template is the new baseline, there is no drift.
+	diags.Append(private.SetKey(ctx, driftReportPrivateKey, nil)...)
+	return diags
+}
+
+// Compares the template read from BioT with the last applied payload, and warns with the fields that changed outside
+// Terraform, once: later refreshes that find the same changes do not warn again. The changes are kept in private state,
+// so the plan can fail on them (fail_on_drift).
+// current is the template as Read saves it in the state (declared builtin attributes only, like the applied payload).
+// Does nothing for templates applied before the payload was kept.
+func reportTemplateDrift(ctx context.Context, prior privateStateGetter, private privateStateSetter, current TerraformTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	encoded, getDiags := prior.GetKey(ctx, appliedPayloadPrivateKey)
+	diags.Append(getDiags...)
+	if diags.HasError() || len(encoded) == 0 {
+		return diags
+	}
+
+	var applied appliedPayload
+	if err := json.Unmarshal(encoded, &applied); err != nil {
+		return diags
+	}
+
+	currentPayload, err := json.Marshal(MapTerraformTemplateToUpdateRequest(ctx, current))
+	if err != nil || payloadHash(currentPayload) == applied.SHA256 {
+		diags.Append(private.SetKey(ctx, driftReportPrivateKey, nil)...)
+		return diags
+	}
+
+	changes := diffTemplatePayloads(applied.Payload, currentPayload)
+	if len(changes) == 0 {
+		diags.Append(private.SetKey(ctx, driftReportPrivateKey, nil)...)
+		return diags
+	}
+
+	report, _ := json.Marshal(changes)
+	diags.Append(private.SetKey(ctx, driftReportPrivateKey, report)...)
+
+	reported, getDiags := loadDriftReport(ctx, prior)
+	diags.Append(getDiags...)
+	if slices.Equal(reported, changes) {
+		tflog.Debug(ctx, "Template changes outside Terraform were already reported", map[string]interface{}{
+			"template_name": current.Name.ValueString(),
+		})
+		return diags
+	}
+
+	diags.AddWarning(
+		"Template changed outside Terraform",
+		fmt.Sprintf("Template [%s] was changed since it was last applied (e.g. in the console):\n  - %s\n\n"+
+			"Apply to revert these changes, or update the configuration to keep them.", current.Name.ValueString(), strings.Join(changes, "\n  - ")),
+	)
+	return diags
+}
+
+func loadDriftReport(ctx context.Context, private privateStateGetter) ([]string, diag.Diagnostics) {
+	encoded, diags := private.GetKey(ctx, driftReportPrivateKey)
+	if diags.HasError() || len(encoded) == 0 {
+		return nil, diags
+	}
+
+	var changes []string
+	_ = json.Unmarshal(encoded, &changes)
+	return changes, diags
+}
+
+func payloadHash(payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:])
+}
+
+// Lists the changed fields between two template payloads, e.g. "customAttributes[weight].validation.mandatory: true -> false".
+// Attributes and selectable values are matched by name.
+func diffTemplatePayloads(applied json.RawMessage, current json.RawMessage) []string {
+	var appliedValue, currentValue interface{}
+	if json.Unmarshal(applied, &appliedValue) != nil || json.Unmarshal(current, &currentValue) != nil {
+		return nil
+	}
+
+	changes := []string{}
+	diffJSONValues("", appliedValue, currentValue, &changes)
+	return changes
+}
+
+func diffJSONValues(fieldPath string, applied interface{}, current interface{}, changes *[]string) {
+	switch {
+	case applied == nil && current == nil:
+		return
+	case applied == nil:
+		*changes = append(*changes, fmt.Sprintf("%s: added%s", fieldPath, formatAddedOrRemovedValue(current)))
+		return
+	case current == nil:
+		*changes = append(*changes, fmt.Sprintf("%s: removed%s", fieldPath, formatAddedOrRemovedValue(applied)))
+		return
+	}
+
+	appliedObject, appliedIsObject := applied.(map[string]interface{})
+	currentObject, currentIsObject := current.(map[string]interface{})
+	if appliedIsObject && currentIsObject {
+		keys := map[string]bool{}
+		for key := range appliedObject {
+			keys[key] = true
+		}
+		for key := range currentObject {
+			keys[key] = true
+		}
+		for _, key := range utils.SortedKeys(keys) {
+			diffJSONValues(joinFieldPath(fieldPath, key), appliedObject[key], currentObject[key], changes)
+		}
+		return
+	}
+
+	appliedByName, appliedIsNamed := elementsByName(applied)
+	currentByName, currentIsNamed := elementsByName(current)
+	if appliedIsNamed && currentIsNamed {
+		names := map[string]bool{}
+		for name := range appliedByName {
+			names[name] = true
+		}
+		for name := range currentByName {
+			names[name] = true
+		}
+		for _, name := range utils.SortedKeys(names) {
+			diffJSONValues(fmt.Sprintf("%s[%s]", fieldPath, name), appliedByName[name], currentByName[name], changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(applied, current) {
+		*changes = append(*changes, fmt.Sprintf("%s: %s -> %s", fieldPath, formatJSONValue(applied), formatJSONValue(current)))
+	}
+}
+
+// Returns the elements of an array of named objects (attributes, selectable values) keyed by name.
+func elementsByName(value interface{}) (map[string]interface{}, bool) {
+	elements, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	byName := make(map[string]interface{}, len(elements))
+	for _, element := range elements {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok || byName[name] != nil {
+			return nil, false
+		}
+		byName[name] = object
+	}
+	return byName, true
+}
+
+func joinFieldPath(fieldPath string, field string) string {
+	if fieldPath == "" {
+		return field
+	}
+	return fieldPath + "." + field
+}
+
+// Whole objects (e.g. an attribute) are not listed, only their name.
+func formatAddedOrRemovedValue(value interface{}) string {
+	if _, isObject := value.(map[string]interface{}); isObject {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", formatJSONValue(value))
+}
+
+func formatJSONValue(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
+// With fail_on_drift, the changes found by the last refresh fail a plan that leaves them in place. A plan that updates
+// the template only reverts the fields it changes: fields that are not configured are not sent, and BioT keeps their
+// current value, so the plan fails unless it changes every drifted field.
+func failOnDrift(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var failOnDrift types.Bool
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("fail_on_drift"), &failOnDrift)...)
+	if diags.HasError() || !failOnDrift.ValueBool() {
+		return diags
+	}
+
+	changes, getDiags := loadDriftReport(ctx, req.Private)
+	diags.Append(getDiags...)
+	if len(changes) == 0 {
+		return diags
+	}
+
+	var state, plan TerraformTemplate
+	diags.Append(req.State.Get(ctx, &state)...)
+	diags.Append(req.Plan.Get(ctx, &plan)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	statePayload, stateErr := json.Marshal(MapTerraformTemplateToUpdateRequest(ctx, state))
+	planPayload, planErr := json.Marshal(MapTerraformTemplateToUpdateRequest(ctx, plan))
+	if stateErr != nil || planErr != nil {
+		return diags
+	}
+
+	kept := driftKeptByPlan(changes, diffTemplatePayloads(statePayload, planPayload))
+	if len(kept) == 0 {
+		return diags
+	}
+
+	diags.AddError(
+		"Template changed outside Terraform",
+		fmt.Sprintf("fail_on_drift is true and the template was changed since it was last applied:\n  - %s\n\n"+
+			"The plan does not change these fields, so it would leave these changes in place. "+
+			"Update the configuration to revert them, or set fail_on_drift = false for one apply to keep them.",
+			strings.Join(kept, "\n  - ")),
+	)
+	return diags
+}
+
+// Returns the drifted changes that are not reverted by the planned changes. A change is reverted when the plan changes
+// the same field, a field in it (e.g. a value of an attribute that was changed) or the object that has it (e.g. an
+// attribute that is removed).
+func driftKeptByPlan(drifted []string, planned []string) []string {
+	plannedPaths := make([]string, 0, len(planned))
+	for _, change := range planned {
+		plannedPaths = append(plannedPaths, changedFieldPath(change))
+	}
+
+	kept := []string{}
+	for _, change := range drifted {
+		driftedPath := changedFieldPath(change)
+		reverted := slices.ContainsFunc(plannedPaths, func(plannedPath string) bool {
+			return isSameOrNestedFieldPath(driftedPath, plannedPath) || isSameOrNestedFieldPath(plannedPath, driftedPath)
+		})
+		if !reverted {
+			kept = append(kept, change)
+		}
+	}
+	return kept
+}
+
+// The field path of a change listed by diffTemplatePayloads, e.g. "customAttributes[weight].phi" for
+// "customAttributes[weight].phi: false -> true".
+func changedFieldPath(change string) string {
+	fieldPath, _, _ := strings.Cut(change, ": ")
+	return fieldPath
+}
+
+func isSameOrNestedFieldPath(fieldPath string, parent string) bool {
+	if fieldPath == parent {
+		return true
+	}
+	rest, ok := strings.CutPrefix(fieldPath, parent)
+	return ok && (strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "["))
+}
@@ -0,0 +1,196 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+// The private state of the response starts as a copy of the prior one.
+func (p testPrivateState) clone() testPrivateState {
+	cloned := testPrivateState{}
+	for key, value := range p {
+		cloned[key] = value
+	}
+	return cloned
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestDiffJSONValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		applied string
+		current string
+		want    []string
+	}{
+		{
+			name:    "equal",
+			applied: `{"name": "device", "customAttributes": [{"name": "weight", "phi": false}]}`,
+			current: `{"customAttributes": [{"phi": false, "name": "weight"}], "name": "device"}`,
+			want:    []string{},
+		},
+		{
+			name:    "changed fields",
+			applied: `{"displayName": "Device", "customAttributes": [{"name": "weight", "validation": {"mandatory": true}}]}`,
+			current: `{"displayName": "Device 2", "customAttributes": [{"name": "weight", "validation": {"mandatory": false}}]}`,
+			want: []string{
+				`customAttributes[weight].validation.mandatory: true -> false`,
+				`displayName: "Device" -> "Device 2"`,
+			},
+		},
+		{
+			name:    "attributes are matched by name, not by position",
+			applied: `{"customAttributes": [{"name": "a", "phi": false}, {"name": "b", "phi": false}]}`,
+			current: `{"customAttributes": [{"name": "b", "phi": true}, {"name": "a", "phi": false}]}`,
+			want:    []string{`customAttributes[b].phi: false -> true`},
+		},
+		{
+			name:    "added and removed attributes are listed by name",
+			applied: `{"customAttributes": [{"name": "a"}, {"name": "b"}]}`,
+			current: `{"customAttributes": [{"name": "a"}, {"name": "c"}]}`,
+			want: []string{
+				`customAttributes[b]: removed`,
+				`customAttributes[c]: added`,
+			},
+		},
+		{
+			name:    "added and removed values are listed with the value",
+			applied: `{"description": "old"}`,
+			current: `{"regex": "^[a-z]+$"}`,
+			want: []string{
+				`description: removed ("old")`,
+				`regex: added ("^[a-z]+$")`,
+			},
+		},
+		{
+			name:    "selectable values are matched by name",
+			applied: `{"customAttributes": [{"name": "color", "selectableValues": [{"name": "red", "displayName": "Red"}]}]}`,
+			current: `{"customAttributes": [{"name": "color", "selectableValues": [{"name": "red", "displayName": "Dark red"}]}]}`,
+			want:    []string{`customAttributes[color].selectableValues[red].displayName: "Red" -> "Dark red"`},
+		},
+		{
+			name:    "arrays without names are compared as a whole",
+			applied: `{"values": [1, 2]}`,
+			current: `{"values": [2, 1]}`,
+			want:    []string{`values: [1,2] -> [2,1]`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var applied, current interface{}
+			if err := json.Unmarshal([]byte(test.applied), &applied); err != nil {
+				t.Fatalf("invalid applied payload: %s", err)
+			}
+			if err := json.Unmarshal([]byte(test.current), &current); err != nil {
+				t.Fatalf("invalid current payload: %s", err)
+			}
+
+			changes := []string{}
+			diffJSONValues("", applied, current, &changes)
+			if !reflect.DeepEqual(changes, test.want) {
+				t.Errorf("changes:\n got: %q\nwant: %q", changes, test.want)
+			}
+		})
+	}
+}
+
+func TestReportTemplateDrift(t *testing.T) {
+	ctx := context.Background()
+	applied := TerraformTemplate{TerraformTemplateDefinition: TerraformTemplateDefinition{Name: types.StringValue("device"), DisplayName: types.StringValue("Device")}}
+	changed := applied
+	changed.DisplayName = types.StringValue("Device 2")
+
+	private := testPrivateState{}
+	if diags := saveAppliedPayload(ctx, private, applied); diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	refresh := func(current TerraformTemplate) diag.Diagnostics {
+		prior := private
+		private = prior.clone()
+		return reportTemplateDrift(ctx, prior, private, current)
+	}
+
+	if diags := refresh(applied); diags.WarningsCount() != 0 {
+		t.Errorf("unchanged template: got warnings %v", diags.Warnings())
+	}
+
+	if diags := refresh(changed); diags.WarningsCount() != 1 {
+		t.Errorf("changed template: got %d warnings, want 1", diags.WarningsCount())
+	}
+	report, _ := loadDriftReport(ctx, private)
+	if want := []string{`displayName: "Device" -> "Device 2"`}; !reflect.DeepEqual(report, want) {
+		t.Errorf("drift report: got %q, want %q", report, want)
+	}
+
+	if diags := refresh(changed); diags.WarningsCount() != 0 {
+		t.Errorf("changes already reported: got warnings %v", diags.Warnings())
+	}
+}
+
+func TestDriftKeptByPlan(t *testing.T) {
+	drifted := []string{
+		`displayName: "Device" -> "Device 2"`,
+		`customAttributes[weight].validation.mandatory: true -> false`,
+		`customAttributes[height]: added`,
+	}
+
+	tests := []struct {
+		name    string
+		planned []string
+		want    []string
+	}{
+		{
+			name:    "plan does not change the drifted fields",
+			planned: []string{`description: "a" -> "b"`},
+			want:    drifted,
+		},
+		{
+			name: "plan changes every drifted field",
+			planned: []string{
+				`displayName: "Device 2" -> "Device"`,
+				`customAttributes[weight].validation.mandatory: false -> true`,
+				`customAttributes[height]: removed`,
+			},
+			want: []string{},
+		},
+		{
+			name:    "plan replaces the object of a drifted field",
+			planned: []string{`customAttributes[weight]: removed`},
+			want:    []string{`displayName: "Device" -> "Device 2"`, `customAttributes[height]: added`},
+		},
+		{
+			name:    "plan changes a field in a drifted object",
+			planned: []string{`customAttributes[height].phi: true -> false`},
+			want:    []string{`displayName: "Device" -> "Device 2"`, `customAttributes[weight].validation.mandatory: true -> false`},
+		},
+		{
+			name:    "field with the same prefix is another field",
+			planned: []string{`displayNameSuffix: "a" -> "b"`},
+			want:    drifted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := driftKeptByPlan(drifted, test.planned); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("kept changes:\n got: %q\nwant: %q", got, test.want)
+			}
+		})
+	}
+}
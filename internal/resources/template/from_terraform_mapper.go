@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
 	"biot.com/terraform-provider-biot-gen2/internal/api"
 	"biot.com/terraform-provider-biot-gen2/internal/utils"
 )
@@ -34,7 +36,6 @@ func MapTerraformTemplateToUpdateRequest(ctx context.Context, t TerraformTemplat
 			OwnerOrganizationID:      utils.StringOrNilPtr(t.OwnerOrganizationID),
 			AnalyticsDbConfiguration: mapAnalyticsDbConfig(ctx, t.AnalyticsDbConfiguration),
 		},
-		ParentTemplateID:   utils.StringOrNilPtr(t.ParentTemplateID),
 		BuiltInAttributes:  mapBuiltinAttributes(ctx, t.BuiltInAttributes),
 		CustomAttributes:   mapCustomAttributes(ctx, t.CustomAttributes),
 		TemplateAttributes: mapTemplateAttributes(ctx, t.TemplateAttributes),
@@ -51,6 +52,19 @@ func mapAnalyticsDbConfig(ctx context.Context, c *TerraformAnalyticsDbConfigurat
 	}
 }
 
+// An unset category is left out of builtin and custom attribute requests (omitempty), BioT then decides the category.
+func mapCategoryName(category types.Object) string {
+	if category.IsNull() || category.IsUnknown() {
+		return ""
+	}
+
+	name, ok := category.Attributes()["name"].(types.String)
+	if !ok || name.IsUnknown() {
+		return ""
+	}
+	return name.ValueString()
+}
+
 func mapBaseAttribute(ctx context.Context, attr BaseTerraformAttribute) api.BaseAttribute {
 	return api.BaseAttribute{
 		Name:                   attr.Name.ValueString(),
@@ -63,37 +77,45 @@ func mapBaseAttribute(ctx context.Context, attr BaseTerraformAttribute) api.Base
 		Validation:             mapValidation(attr.Validation),
 		NumericMetaData:        mapNumericMetaData(attr.NumericMetaData),
 		Type:                   attr.Type.ValueString(),
-		SelectableValues:       mapSelectableValues(attr.Name.ValueString(), attr.SelectableValues),
+		SelectableValues:       mapSelectableValues(attr.Type.ValueString(), attr.SelectableValues, attr.AllowedValues),
 	}
 }
 
-func mapCustomAttributes(ctx context.Context, attrs []TerraformCustomAttribute) []api.CustomAttributeRequest {
+func mapCustomAttributes(ctx context.Context, attrs map[string]TerraformCustomAttribute) []api.CustomAttributeRequest {
 	result := []api.CustomAttributeRequest{}
-	for _, attr := range attrs {
+	for _, name := range utils.SortedKeys(attrs) {
+		attr := attrs[name]
+		attr.Name = types.StringValue(name)
 		result = append(result, api.CustomAttributeRequest{
 			BaseAttribute:            mapBaseAttribute(ctx, attr.BaseTerraformAttribute),
-			Category:                 attr.Category.ValueString(),
+			Category:                 mapCategoryName(attr.Category),
 			AnalyticsDbConfiguration: mapAnalyticsDbConfig(ctx, attr.AnalyticsDbConfiguration),
 		})
 	}
 	return result
 }
 
-func mapBuiltinAttributes(ctx context.Context, attrs []TerraformBuiltinAttribute) []api.BuiltinAttributeRequest {
+func mapBuiltinAttributes(ctx context.Context, attrs map[string]TerraformBuiltinAttribute) []api.BuiltinAttributeRequest {
 	result := []api.BuiltinAttributeRequest{}
-	for _, attr := range attrs {
+	for _, name := range utils.SortedKeys(attrs) {
+		attr := attrs[name]
+		attr.Name = types.StringValue(name)
 		result = append(result, api.BuiltinAttributeRequest{
 			BaseAttribute:            mapBaseAttribute(ctx, attr.BaseTerraformAttribute),
+			Category:                 mapCategoryName(attr.Category),
 			AnalyticsDbConfiguration: mapAnalyticsDbConfig(ctx, attr.AnalyticsDbConfiguration),
 		})
 	}
 	return result
 }
 
-func mapTemplateAttributes(ctx context.Context, attrs []TerraformTemplateAttribute) []api.TemplateAttributeRequest {
+func mapTemplateAttributes(ctx context.Context, attrs map[string]TerraformTemplateAttribute) []api.TemplateAttributeRequest {
 	result := make([]api.TemplateAttributeRequest, 0, len(attrs))
 
-	for _, attr := range attrs {
+	for _, name := range utils.SortedKeys(attrs) {
+		attr := attrs[name]
+		attr.Name = types.StringValue(name)
+
 		var value interface{}
 
 		if !attr.Value.IsNull() && !attr.Value.IsUnknown() {
@@ -129,7 +151,7 @@ func mapReferenceConfiguration(rc *TerraformReferenceConfiguration) *api.Referen
 		Uniquely:                           rc.Uniquely.ValueBool(),
 		ReferencedSideAttributeName:        rc.ReferencedSideAttributeName.ValueString(),
 		ReferencedSideAttributeDisplayName: rc.ReferencedSideAttributeDisplayName.ValueString(),
-		ValidTemplatesToReference:          utils.ConvertTerraformStringList(rc.ValidTemplatesToReference),
+		ValidTemplatesToReference:          utils.ConvertTerraformStringListValue(rc.ValidTemplatesToReference),
 		EntityType:                         rc.EntityType.ValueString(),
 	}
 }
@@ -186,10 +208,22 @@ func mapNumericMetaData(numericMetaData *TerraformNumericMetaData) *api.NumericM
 	}
 }
 
-func mapSelectableValues(attributeType string, vals []TerraformSelectableValue) []api.SelectableValue {
+func mapSelectableValues(attributeType string, vals []TerraformSelectableValue, allowedValues []types.String) []api.SelectableValue {
 	result := []api.SelectableValue{}
-	if attributeType == "TIMEZONE" || attributeType == "LOCALE" {
-		return []api.SelectableValue{}
+
+	// Timezone and Locale attributes take their values from the platform catalog, allowed_values restricts them to
+	// a subset of the catalog. An empty list means the whole catalog.
+	if hasAllowedValuesCatalog(attributeType) {
+		for _, value := range allowedValues {
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			result = append(result, api.SelectableValue{
+				Name:        value.ValueString(),
+				DisplayName: value.ValueString(),
+			})
+		}
+		return result
 	}
 
 	for _, val := range vals {
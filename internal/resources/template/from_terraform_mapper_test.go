@@ -0,0 +1,44 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParentTemplateIsSentOnCreateOnly(t *testing.T) {
+	ctx := context.Background()
+
+	template := TerraformTemplate{}
+	template.Name = types.StringValue("device")
+	template.EntityTypeName = types.StringValue("device")
+	template.ParentTemplateID = types.StringValue("3fa85f64-5717-4562-b3fc-2c963f66afa6")
+
+	fields := func(request interface{}) map[string]interface{} {
+		t.Helper()
+
+		body, err := json.Marshal(request)
+		if err != nil {
+			t.Fatalf("failed to marshal the request: %s", err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			t.Fatalf("invalid request: %s", err)
+		}
+		return decoded
+	}
+
+	create := fields(MapTerraformTemplateToCreateRequest(ctx, template))
+	if create["parentTemplateId"] != template.ParentTemplateID.ValueString() || create["entityType"] != "device" {
+		t.Errorf("create request: got parentTemplateId %v and entityType %v", create["parentTemplateId"], create["entityType"])
+	}
+
+	update := fields(MapTerraformTemplateToUpdateRequest(ctx, template))
+	for _, field := range []string{"parentTemplateId", "entityType"} {
+		if _, ok := update[field]; ok {
+			t.Errorf("update request: got %s, it can only be set on create", field)
+		}
+	}
+}
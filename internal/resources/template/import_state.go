@@ -0,0 +1,163 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+const importIDFormats = `Expected one of:
+  - template ID (e.g. "3fa85f64-5717-4562-b3fc-2c963f66afa6")
+  - "entity-type:template-name" (e.g. "caregiver:doctor")
+  - "entity-type:\"template-name\"" or "entity-type:template\:name" for names that contain ':'`
+
+type TerraformTemplateIdentity struct {
+	EntityType types.String `tfsdk:"entity_type"`
+	Name       types.String `tfsdk:"name"`
+}
+
+// Used by import blocks with an identity, and by the results of terraform query (see BiotTemplateListResource).
+func (r *BiotTemplateResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"entity_type": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The entity type of the template.",
+			},
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The name of the template.",
+			},
+		},
+	}
+}
+
+// Import state works with a template ID, entity-type:template-name, or an import block identity.
+func (r *BiotTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var templateResponse api.TemplateResponse
+	var err error
+
+	switch {
+	case req.ID == "" && req.Identity != nil:
+		var identity TerraformTemplateIdentity
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		templateResponse, err = r.importByTypeAndName(ctx, identity.EntityType.ValueString(), identity.Name.ValueString())
+
+	case uuidPattern.MatchString(req.ID):
+		tflog.Debug(ctx, "Starting template import by ID", map[string]interface{}{
+			"template_id": req.ID,
+		})
+		templateResponse, err = r.client.GetTemplate(ctx, req.ID)
+
+	default:
+		entityType, templateName, parseErr := parseTemplateImportID(req.ID)
+		if parseErr != nil {
+			resp.Diagnostics.AddError("Invalid import ID format", fmt.Sprintf("%s\n\n%s", parseErr, importIDFormats))
+			return
+		}
+		templateResponse, err = r.importByTypeAndName(ctx, entityType, templateName)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to import template [%s]", req.ID), err.Error())
+		return
+	}
+	tflog.Debug(ctx, "Successfully retrieved template for import", map[string]interface{}{
+		"template_name": templateResponse.Name,
+		"template_id":   templateResponse.ID,
+	})
+
+	tfModel := mapImportedTemplate(ctx, templateResponse)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfModel)...)
+	resp.Diagnostics.Append(setTemplateIdentity(ctx, resp.Identity, tfModel)...)
+	resp.Diagnostics.Append(saveTemplateDocument(ctx, resp.Private, templateResponse)...)
+	resp.Diagnostics.Append(saveAppliedPayload(ctx, resp.Private, tfModel)...)
+}
+
+// The model of an imported (or listed) template, with the default resource options.
+func mapImportedTemplate(ctx context.Context, templateResponse api.TemplateResponse) TerraformTemplate {
+	tfModel := mapTemplateResponseToTerrformModel(ctx, templateResponse)
+	copyAttributeOptions(TerraformTemplate{}, &tfModel)
+	tfModel.BuiltinAttributesManagement = types.StringValue(builtinAttributesManagementAll)
+	return tfModel
+}
+
+func (r *BiotTemplateResource) importByTypeAndName(ctx context.Context, entityType string, templateName string) (api.TemplateResponse, error) {
+	tflog.Debug(ctx, "Starting template import", map[string]interface{}{
+		"entity_type":   entityType,
+		"template_name": templateName,
+	})
+
+	templateResponse, err := r.client.GetTemplateByTypeAndName(ctx, entityType, templateName)
+	if err != nil {
+		return api.TemplateResponse{}, fmt.Errorf("entityType: %q and name: %q: %w", entityType, templateName, err)
+	}
+
+	return templateResponse, nil
+}
+
+// Splits "entity-type:template-name" on the first unescaped ':'.
+// The template name may be quoted ("name:with:colons") or escaped (name\:with\:colons).
+func parseTemplateImportID(id string) (string, string, error) {
+	separator := -1
+	for i := 0; i < len(id); i++ {
+		if id[i] == '\\' {
+			i++
+			continue
+		}
+		if id[i] == ':' {
+			separator = i
+			break
+		}
+	}
+
+	if separator <= 0 || separator == len(id)-1 {
+		return "", "", fmt.Errorf("import ID [%s] is not a template ID or an entity-type:template-name pair", id)
+	}
+
+	entityType := unescapeImportIDPart(id[:separator])
+	templateName := id[separator+1:]
+
+	if strings.HasPrefix(templateName, `"`) {
+		unquoted, err := strconv.Unquote(templateName)
+		if err != nil {
+			return "", "", fmt.Errorf("template name [%s] is not a valid quoted string: %w", templateName, err)
+		}
+		return entityType, unquoted, nil
+	}
+
+	return entityType, unescapeImportIDPart(templateName), nil
+}
+
+func unescapeImportIDPart(part string) string {
+	return strings.NewReplacer(`\:`, ":", `\\`, `\`).Replace(part)
+}
+
+func setTemplateIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, template TerraformTemplate) diag.Diagnostics {
+	// Terraform versions before 1.12 do not support resource identity.
+	if identity == nil {
+		return nil
+	}
+
+	return identity.Set(ctx, TerraformTemplateIdentity{
+		EntityType: template.EntityTypeName,
+		Name:       template.Name,
+	})
+}
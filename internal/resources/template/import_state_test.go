@@ -0,0 +1,44 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestParseTemplateImportID(t *testing.T) {
+	tests := []struct {
+		id             string
+		wantEntityType string
+		wantName       string
+		wantErr        bool
+	}{
+		{id: "device:blood_pressure", wantEntityType: "device", wantName: "blood_pressure"},
+		{id: "device:name:with:colons", wantEntityType: "device", wantName: "name:with:colons"},
+		{id: `device:"quoted:name"`, wantEntityType: "device", wantName: "quoted:name"},
+		{id: `device:"quoted \"name\""`, wantEntityType: "device", wantName: `quoted "name"`},
+		{id: `device:escaped\:name`, wantEntityType: "device", wantName: "escaped:name"},
+		{id: `custom\:type:name`, wantEntityType: "custom:type", wantName: "name"},
+		{id: `back\\slash:name`, wantEntityType: `back\slash`, wantName: "name"},
+		{id: "3fa85f64-5717-4562-b3fc-2c963f66afa6", wantErr: true},
+		{id: ":name", wantErr: true},
+		{id: "device:", wantErr: true},
+		{id: `device:"unterminated`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			entityType, name, err := parseTemplateImportID(test.id)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got entity type %q and name %q", entityType, name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if entityType != test.wantEntityType || name != test.wantName {
+				t.Errorf("got entity type %q and name %q, want %q and %q", entityType, name, test.wantEntityType, test.wantName)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package template
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	// The time zone catalog is embedded, so time zones are validated the same way on every machine.
+	_ "time/tzdata"
+
+	"golang.org/x/text/language"
+)
+
+// The kind of JSON value BioT expects in validation.defaultValue for an attribute type.
+type defaultValueKind int
+
+const (
+	defaultValueAny defaultValueKind = iota
+	defaultValueString
+	defaultValueInteger
+	defaultValueNumber
+	defaultValueBoolean
+	defaultValueSelectableName
+	defaultValueSelectableNames
+)
+
+// Describes which optional configuration blocks are meaningful for a BioT attribute type.
+type attributeTypeSpec struct {
+	selectableValues       bool
+	numericMetaData        bool
+	referenceConfiguration bool
+	defaultValue           defaultValueKind
+	// Types whose selectable values come from a platform catalog (time zones, locales) and can only be restricted
+	// with allowed_values. Reports whether a value is in the catalog.
+	allowedValuesCatalog func(value string) bool
+}
+
+// The single table of BioT attribute types known to the provider.
+// Other types are accepted with a warning (BioT may add types), and are sent without the type-specific checks.
+var attributeTypes = map[string]attributeTypeSpec{
+	"LABEL":         {defaultValue: defaultValueString},
+	"PARAGRAPH":     {defaultValue: defaultValueString},
+	"EMAIL":         {defaultValue: defaultValueString},
+	"PHONE":         {defaultValue: defaultValueString},
+	"INTEGER":       {numericMetaData: true, defaultValue: defaultValueInteger},
+	"DECIMAL":       {numericMetaData: true, defaultValue: defaultValueNumber},
+	"BOOLEAN":       {defaultValue: defaultValueBoolean},
+	"SINGLE_SELECT": {selectableValues: true, defaultValue: defaultValueSelectableName},
+	"MULTI_SELECT":  {selectableValues: true, defaultValue: defaultValueSelectableNames},
+	"REFERENCE":     {referenceConfiguration: true},
+	"TIMEZONE":      {defaultValue: defaultValueString, allowedValuesCatalog: isKnownTimeZone},
+	"LOCALE":        {defaultValue: defaultValueString, allowedValuesCatalog: isKnownLocale},
+	"DATE":          {},
+	"DATE_TIME":     {},
+	"TIME":          {},
+	"ADDRESS":       {},
+	"NAME":          {},
+	"FILE":          {},
+	"IMAGE":         {},
+	"LINK":          {},
+	"UUID":          {},
+	"CODE":          {},
+	"WAVEFORM":      {numericMetaData: true},
+}
+
+// BioT entity types that can have templates, other entity types are accepted with a warning.
+var entityTypes = []string{
+	"caregiver",
+	"command",
+	"device",
+	"device-alert",
+	"generic-entity",
+	"observation",
+	"organization",
+	"organization-user",
+	"patient",
+	"patient-alert",
+	"registration-code",
+	"sensor",
+	"usage-session",
+}
+
+// Values of numeric_meta_data.sub_type, the numeric representation of INTEGER / DECIMAL / WAVEFORM values.
+// Other values are accepted with a warning.
+var numericSubTypes = []string{
+	"DECIMAL",
+	"INTEGER",
+}
+
+// Attribute categories BioT supports for an entity type, in addition to REGULAR which every entity type supports.
+// Maintained in the provider, so a category that is not listed for an entity type is only a warning.
+var attributeCategoriesByEntityType = map[string][]string{
+	"device":        {"MEASUREMENT", "STATUS"},
+	"patient":       {"MEASUREMENT"},
+	"usage-session": {"MEASUREMENT", "STATUS"},
+}
+
+func attributeCategoriesOf(entityType string) []string {
+	return append([]string{"REGULAR"}, attributeCategoriesByEntityType[entityType]...)
+}
+
+// Every category of every entity type.
+func allAttributeCategories() []string {
+	categories := map[string]bool{"REGULAR": true}
+	for _, entityCategories := range attributeCategoriesByEntityType {
+		for _, category := range entityCategories {
+			categories[category] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(categories))
+}
+
+func sortedAttributeTypes() []string {
+	return slices.Sorted(maps.Keys(attributeTypes))
+}
+
+// IANA time zone IDs, e.g. "Europe/London". Checked against the embedded Go catalog, an unknown value is only a warning.
+func isKnownTimeZone(value string) bool {
+	if value == "" || value == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(value)
+	return err == nil
+}
+
+// BCP 47 language tags, BioT uses both the "en-US" and "en_US" forms.
+func isKnownLocale(value string) bool {
+	if value == "" {
+		return false
+	}
+	_, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+	return err == nil
+}
+
+func hasAllowedValuesCatalog(attributeType string) bool {
+	return attributeTypes[attributeType].allowedValuesCatalog != nil
+}
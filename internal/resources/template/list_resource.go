@@ -0,0 +1,100 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	biotvalidators "biot.com/terraform-provider-biot-gen2/internal/resources/biot_validators"
+)
+
+func NewTemplateListResource() list.ListResource {
+	return &BiotTemplateListResource{}
+}
+
+// Lists the templates of BioT for terraform query (Terraform 1.14 or later), with the identity of biot_template.
+type BiotTemplateListResource struct {
+	client *api.APIClient
+}
+
+type TerraformTemplateListConfig struct {
+	EntityType types.String `tfsdk:"entity_type"`
+}
+
+var _ list.ListResourceWithConfigure = &BiotTemplateListResource{}
+
+func (r *BiotTemplateListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_template"
+}
+
+func (r *BiotTemplateListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotTemplateListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists BioT templates, e.g. to generate `import` blocks for existing templates with `terraform query`.",
+		Attributes: map[string]schema.Attribute{
+			"entity_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Lists only the templates of this entity type. Known values: %s.", biotvalidators.FormatMarkdownValues(entityTypes)),
+				Validators: []validator.String{
+					biotvalidators.KnownValuesWithSuggestion(entityTypes),
+				},
+			},
+		},
+	}
+}
+
+func (r *BiotTemplateListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config TerraformTemplateListConfig
+	if diags := req.Config.Get(ctx, &config); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	templates, err := r.client.ListTemplates(ctx, config.EntityType.ValueString(), int(req.Limit))
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("API Error", fmt.Sprintf("Failed to list templates: %s", err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, template := range templates {
+			if !push(newTemplateListResult(ctx, req, template)) {
+				return
+			}
+		}
+	}
+}
+
+func newTemplateListResult(ctx context.Context, req list.ListRequest, template api.TemplateResponse) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = fmt.Sprintf("%s:%s", template.EntityTypeName, template.Name)
+
+	tfModel := mapImportedTemplate(ctx, template)
+	result.Diagnostics.Append(setTemplateIdentity(ctx, result.Identity, tfModel)...)
+	if req.IncludeResource {
+		result.Diagnostics.Append(result.Resource.Set(ctx, tfModel)...)
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+func TestNewTemplateListResult(t *testing.T) {
+	ctx := context.Background()
+
+	templateResource := &BiotTemplateResource{}
+	schemaResp := &resource.SchemaResponse{}
+	templateResource.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	templateResource.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	template := api.TemplateResponse{ID: "3fa85f64-5717-4562-b3fc-2c963f66afa6", EntityTypeName: "device"}
+	template.Name = "blood_pressure"
+	template.DisplayName = "Blood pressure"
+
+	for _, includeResource := range []bool{false, true} {
+		req := list.ListRequest{
+			IncludeResource:        includeResource,
+			ResourceSchema:         schemaResp.Schema,
+			ResourceIdentitySchema: identityResp.IdentitySchema,
+		}
+
+		result := newTemplateListResult(ctx, req, template)
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected errors: %v", result.Diagnostics.Errors())
+		}
+		if result.DisplayName != "device:blood_pressure" {
+			t.Errorf("display name: got %q", result.DisplayName)
+		}
+
+		var identity TerraformTemplateIdentity
+		if diags := result.Identity.Get(ctx, &identity); diags.HasError() {
+			t.Fatalf("invalid identity: %v", diags.Errors())
+		}
+		if identity.EntityType.ValueString() != "device" || identity.Name.ValueString() != "blood_pressure" {
+			t.Errorf("identity: got %s:%s", identity.EntityType, identity.Name)
+		}
+
+		if result.Resource.Raw.IsNull() == includeResource {
+			t.Errorf("resource is set: %t, want %t", !result.Resource.Raw.IsNull(), includeResource)
+		}
+		if includeResource {
+			var model TerraformTemplate
+			if diags := result.Resource.Get(ctx, &model); diags.HasError() {
+				t.Fatalf("invalid resource: %v", diags.Errors())
+			}
+			if model.ID.ValueString() != template.ID || model.BuiltinAttributesManagement.ValueString() != builtinAttributesManagementAll {
+				t.Errorf("resource: got id %s and builtin_attributes_management %s", model.ID, model.BuiltinAttributesManagement)
+			}
+		}
+	}
+}
@@ -0,0 +1,523 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+var attributeCollections = []string{"builtin_attributes", "custom_attributes", "template_attributes"}
+
+// A problem found in a single attribute.
+// fields is the path of the problematic field, relative to the attribute (e.g. ["validation", "regex"]).
+// A warning is reported for checks that BioT may not agree with (e.g. a regex that Go does not support).
+type attributeProblem struct {
+	fields  []string
+	summary string
+	detail  string
+	warning bool
+}
+
+// Reports the problem on the field it was found in, under the attribute at attributePath.
+func (problem attributeProblem) addTo(diags *diag.Diagnostics, attributePath path.Path) {
+	problemPath := attributePath
+	for _, field := range problem.fields {
+		problemPath = problemPath.AtName(field)
+	}
+	if problem.warning {
+		diags.AddAttributeWarning(problemPath, problem.summary, problem.detail)
+	} else {
+		diags.AddAttributeError(problemPath, problem.summary, problem.detail)
+	}
+}
+
+// Checks that can be done on a template request without calling BioT.
+// Used when the server-side validation endpoint is not available.
+func validateTemplateRequestLocally(builtinAttributes []api.BuiltinAttributeRequest, customAttributes []api.CustomAttributeRequest, templateAttributes []api.TemplateAttributeRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	attributes := []api.BaseAttribute{}
+	for _, attr := range builtinAttributes {
+		attributes = append(attributes, attr.BaseAttribute)
+	}
+	for _, attr := range customAttributes {
+		attributes = append(attributes, attr.BaseAttribute)
+	}
+	for _, attr := range templateAttributes {
+		attributes = append(attributes, attr.BaseAttribute)
+	}
+
+	seenNames := map[string]bool{}
+	for _, attr := range attributes {
+		if seenNames[attr.Name] {
+			diags.AddError(
+				"Duplicate attribute name",
+				fmt.Sprintf("Attribute name [%s] is used more than once in the template. Attribute names must be unique across builtin, custom and template attributes.", attr.Name),
+			)
+		}
+		seenNames[attr.Name] = true
+
+		for _, problem := range checkAttribute(attr) {
+			if problem.warning {
+				diags.AddWarning(problem.summary, fmt.Sprintf("Attribute [%s]: %s", attr.Name, problem.detail))
+			} else {
+				diags.AddError(problem.summary, fmt.Sprintf("Attribute [%s]: %s", attr.Name, problem.detail))
+			}
+		}
+	}
+
+	return diags
+}
+
+// Validates the attributes in the configuration, reporting every problem on the exact attribute path.
+// templatePath is the path of the template object (path.Empty() for biot_template).
+// Values that are unknown during validation are skipped, BioT will validate them on apply.
+func validateTemplateConfigAttributes(ctx context.Context, templatePath path.Path, getAttribute func(context.Context, path.Path, interface{}) diag.Diagnostics) diag.Diagnostics {
+	var diags diag.Diagnostics
+	seenNames := map[string]bool{}
+	// previous name -> the attribute that claims it
+	previousNameOwners := map[string]string{}
+
+	var entityType types.String
+	diags.Append(getAttribute(ctx, templatePath.AtName("entity_type"), &entityType)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, collection := range attributeCollections {
+		var attributes types.Map
+		diags.Append(getAttribute(ctx, templatePath.AtName(collection), &attributes)...)
+		if diags.HasError() {
+			return diags
+		}
+		if attributes.IsNull() || attributes.IsUnknown() {
+			continue
+		}
+
+		elements := attributes.Elements()
+		for _, name := range utils.SortedKeys(elements) {
+			attributeObject, ok := elements[name].(types.Object)
+			if !ok || attributeObject.IsNull() || attributeObject.IsUnknown() {
+				continue
+			}
+
+			attribute, ok := decodeBaseAttribute(ctx, collection, attributeObject)
+			if !ok {
+				continue
+			}
+			attribute.Name = types.StringValue(name)
+
+			attributePath := templatePath.AtName(collection).AtMapKey(name)
+
+			if seenNames[name] {
+				diags.AddAttributeError(
+					attributePath,
+					"Duplicate attribute name",
+					fmt.Sprintf("Attribute name [%s] is used more than once in the template. Attribute names must be unique across builtin, custom and template attributes.", name),
+				)
+			}
+			seenNames[name] = true
+
+			diags.Append(checkPreviousNames(collection, name, attribute.PreviousNames, previousNameOwners, attributePath)...)
+			diags.Append(validateAttributeConfig(ctx, attributePath, attribute, entityType)...)
+		}
+	}
+
+	return diags
+}
+
+// The checks of a single configured attribute. attribute.Name must be set.
+// entityType is the entity type of the template, the checks that depend on it are skipped when it is not known.
+func validateAttributeConfig(ctx context.Context, attributePath path.Path, attribute BaseTerraformAttribute, entityType types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	problems := checkNameReferencesConfig(attribute)
+	problems = append(problems, checkAllowedValuesConfig(attribute)...)
+	problems = append(problems, checkCategoryConfig(attribute, entityType)...)
+	problems = append(problems, checkAttribute(mapBaseAttribute(ctx, attribute))...)
+
+	for _, problem := range problems {
+		problem.addTo(&diags, attributePath)
+	}
+
+	return diags
+}
+
+// The name based references are alternatives to the IDs, and need the entity type to find the template by name.
+func checkNameReferencesConfig(attribute BaseTerraformAttribute) []attributeProblem {
+	problems := []attributeProblem{}
+
+	if link := attribute.LinkConfiguration; link != nil {
+		if !link.TemplateName.IsNull() && !link.TemplateID.IsNull() {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"link_configuration", "template_name"},
+				summary: "Conflicting link_configuration",
+				detail:  "Only one of template_id and template_name can be set",
+			})
+		}
+		if !link.TemplateName.IsNull() && link.EntityTypeName.IsNull() {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"link_configuration", "entity_type_name"},
+				summary: "Missing entity_type_name",
+				detail:  "entity_type_name is required to find the linked template by template_name",
+			})
+		}
+		if !link.AttributeName.IsNull() && !link.AttributeID.IsNull() {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"link_configuration", "attribute_name"},
+				summary: "Conflicting link_configuration",
+				detail:  "Only one of attribute_id and attribute_name can be set",
+			})
+		}
+		if !link.AttributeName.IsNull() && link.TemplateName.IsNull() && link.TemplateID.IsNull() {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"link_configuration", "attribute_name"},
+				summary: "Missing linked template",
+				detail:  "attribute_name requires template_id or template_name",
+			})
+		}
+	}
+
+	if reference := attribute.ReferenceConfiguration; reference != nil && reference.ValidTemplateNames != nil {
+		if !reference.ValidTemplatesToReference.IsNull() {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"reference_configuration", "valid_template_names"},
+				summary: "Conflicting reference_configuration",
+				detail:  "Only one of valid_templates_to_reference and valid_template_names can be set",
+			})
+		}
+		if reference.EntityType.IsNull() {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"reference_configuration", "entity_type"},
+				summary: "Missing entity_type",
+				detail:  "entity_type is required to find the referenced templates by valid_template_names",
+			})
+		}
+	}
+
+	return problems
+}
+
+// allowed_values and selectable_values are mapped to the same request field, so the one that does not match the
+// attribute type would be silently ignored.
+func checkAllowedValuesConfig(attribute BaseTerraformAttribute) []attributeProblem {
+	problems := []attributeProblem{}
+	if attribute.Type.IsNull() || attribute.Type.IsUnknown() {
+		return problems
+	}
+
+	if hasAllowedValuesCatalog(attribute.Type.ValueString()) {
+		if len(attribute.SelectableValues) > 0 {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"selectable_values"},
+				summary: "Unsupported selectable_values",
+				detail:  fmt.Sprintf("The values of an attribute of type %s come from the platform catalog, use allowed_values to restrict them", attribute.Type.ValueString()),
+			})
+		}
+	} else if len(attribute.AllowedValues) > 0 {
+		problems = append(problems, attributeProblem{
+			fields:  []string{"allowed_values"},
+			summary: "Unsupported allowed_values",
+			detail:  fmt.Sprintf("allowed_values can not be set on an attribute of type %s, it is supported only for %s", attribute.Type.ValueString(), strings.Join(attributeTypesSupporting(func(s attributeTypeSpec) bool { return s.allowedValuesCatalog != nil }), ", ")),
+		})
+	}
+
+	return problems
+}
+
+// The category names themselves are validated by the schema, only the entity type support is checked here.
+// entity_type is optional on biot_template, the check is skipped when it is not configured.
+func checkCategoryConfig(attribute BaseTerraformAttribute, entityType types.String) []attributeProblem {
+	problems := []attributeProblem{}
+	if !isKnownString(entityType) || attribute.Category.IsNull() || attribute.Category.IsUnknown() {
+		return problems
+	}
+
+	name, ok := attribute.Category.Attributes()["name"].(types.String)
+	if !ok || !isKnownString(name) {
+		return problems
+	}
+	category := name.ValueString()
+
+	supported := attributeCategoriesOf(entityType.ValueString())
+	if !slices.Contains(allAttributeCategories(), category) || slices.Contains(supported, category) {
+		return problems
+	}
+
+	return append(problems, attributeProblem{
+		fields:  []string{"category", "name"},
+		summary: "Unsupported category",
+		detail:  fmt.Sprintf("Category %s is not known to be supported for entity type %s, make sure BioT supports it. Known categories: %s", category, entityType.ValueString(), strings.Join(supported, ", ")),
+		warning: true,
+	})
+}
+
+func checkPreviousNames(collection string, name string, previousNames []types.String, previousNameOwners map[string]string, attributePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(previousNames) == 0 {
+		return diags
+	}
+
+	if collection == "builtin_attributes" {
+		diags.AddAttributeError(
+			attributePath.AtName("previous_names"),
+			"Unsupported previous_names",
+			"Builtin attribute names are defined by BioT and can not be renamed, previous_names is supported only for custom and template attributes.",
+		)
+		return diags
+	}
+
+	for _, previousName := range previousNames {
+		if previousName.IsNull() || previousName.IsUnknown() {
+			continue
+		}
+
+		if owner, claimed := previousNameOwners[previousName.ValueString()]; claimed && owner != name {
+			diags.AddAttributeError(
+				attributePath.AtName("previous_names"),
+				"Ambiguous previous_names",
+				fmt.Sprintf("Previous name [%s] is listed by both [%s] and [%s]. An attribute can be renamed to only one new name.", previousName.ValueString(), owner, name),
+			)
+			continue
+		}
+		previousNameOwners[previousName.ValueString()] = name
+	}
+
+	return diags
+}
+
+// Each attribute collection has its own nested object type, so it has to be decoded to the matching struct.
+func decodeBaseAttribute(ctx context.Context, collection string, attributeObject types.Object) (BaseTerraformAttribute, bool) {
+	var diags diag.Diagnostics
+	var base BaseTerraformAttribute
+
+	switch collection {
+	case "builtin_attributes":
+		var attribute TerraformBuiltinAttribute
+		diags = attributeObject.As(ctx, &attribute, basetypes.ObjectAsOptions{})
+		base = attribute.BaseTerraformAttribute
+	case "custom_attributes":
+		var attribute TerraformCustomAttribute
+		diags = attributeObject.As(ctx, &attribute, basetypes.ObjectAsOptions{})
+		base = attribute.BaseTerraformAttribute
+	case "template_attributes":
+		var attribute TerraformTemplateAttribute
+		diags = attributeObject.As(ctx, &attribute, basetypes.ObjectAsOptions{})
+		base = attribute.BaseTerraformAttribute
+	}
+
+	// Nested values that are still unknown cannot be decoded, those attributes are validated on apply.
+	return base, !diags.HasError()
+}
+
+func checkAttribute(attr api.BaseAttribute) []attributeProblem {
+	problems := []attributeProblem{}
+
+	spec, knownType := attributeTypes[attr.Type]
+	if knownType {
+		// For catalog types the selectable values are the allowed_values, checked below.
+		if len(attr.SelectableValues) > 0 && !spec.selectableValues && spec.allowedValuesCatalog == nil {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"selectable_values"},
+				summary: "Unsupported selectable_values",
+				detail:  fmt.Sprintf("selectable_values can not be set on an attribute of type %s, it is supported only for %s", attr.Type, strings.Join(attributeTypesSupporting(func(s attributeTypeSpec) bool { return s.selectableValues }), ", ")),
+			})
+		}
+
+		if attr.NumericMetaData != nil && !spec.numericMetaData {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"numeric_meta_data"},
+				summary: "Unsupported numeric_meta_data",
+				detail:  fmt.Sprintf("numeric_meta_data can not be set on an attribute of type %s, it is supported only for %s", attr.Type, strings.Join(attributeTypesSupporting(func(s attributeTypeSpec) bool { return s.numericMetaData }), ", ")),
+			})
+		}
+
+		if attr.ReferenceConfiguration != nil && !spec.referenceConfiguration {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"reference_configuration"},
+				summary: "Unsupported reference_configuration",
+				detail:  fmt.Sprintf("reference_configuration can not be set on an attribute of type %s, it is supported only for %s", attr.Type, strings.Join(attributeTypesSupporting(func(s attributeTypeSpec) bool { return s.referenceConfiguration }), ", ")),
+			})
+		}
+	}
+
+	// The catalogs of the provider (Go time zones, BCP 47 locales) are not the ones of BioT, which decides on apply.
+	if knownType && spec.allowedValuesCatalog != nil {
+		for _, selectableValue := range attr.SelectableValues {
+			if !spec.allowedValuesCatalog(selectableValue.Name) {
+				problems = append(problems, attributeProblem{
+					fields:  []string{"allowed_values"},
+					summary: "Unknown allowed value",
+					detail:  fmt.Sprintf("[%s] is not a known %s value, make sure BioT supports it", selectableValue.Name, attr.Type),
+					warning: true,
+				})
+			}
+		}
+	}
+
+	seenSelectableNames := map[string]bool{}
+	seenSelectableIDs := map[string]bool{}
+	for _, selectableValue := range attr.SelectableValues {
+		if seenSelectableNames[selectableValue.Name] {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"selectable_values"},
+				summary: "Duplicate selectable value name",
+				detail:  fmt.Sprintf("Selectable value name [%s] is used more than once", selectableValue.Name),
+			})
+		}
+		seenSelectableNames[selectableValue.Name] = true
+
+		if selectableValue.ID == "" {
+			continue
+		}
+		if seenSelectableIDs[selectableValue.ID] {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"selectable_values"},
+				summary: "Duplicate selectable value id",
+				detail:  fmt.Sprintf("Selectable value id [%s] is used more than once", selectableValue.ID),
+			})
+		}
+		seenSelectableIDs[selectableValue.ID] = true
+	}
+
+	if attr.Validation != nil {
+		if attr.Validation.Regex != nil {
+			// BioT evaluates the regex with Java, which supports more than Go's RE2 (e.g. lookarounds and backreferences).
+			if _, err := regexp.Compile(*attr.Validation.Regex); err != nil {
+				problems = append(problems, attributeProblem{
+					fields:  []string{"validation", "regex"},
+					summary: "Unverified validation regex",
+					detail:  fmt.Sprintf("The regex could not be checked locally, make sure BioT accepts it: %s", err),
+					warning: true,
+				})
+			}
+		}
+
+		if attr.Validation.Min != nil && attr.Validation.Max != nil && *attr.Validation.Min > *attr.Validation.Max {
+			problems = append(problems, attributeProblem{
+				fields:  []string{"validation", "min"},
+				summary: "Invalid validation range",
+				detail:  fmt.Sprintf("min [%g] is greater than max [%g]", *attr.Validation.Min, *attr.Validation.Max),
+			})
+		}
+
+		if attr.Validation.DefaultValue != nil && *attr.Validation.DefaultValue != "" && knownType {
+			if detail := checkDefaultValue(spec, attr, *attr.Validation.DefaultValue); detail != "" {
+				problems = append(problems, attributeProblem{
+					fields:  []string{"validation", "default_value"},
+					summary: "Invalid default_value",
+					detail:  detail,
+				})
+			}
+		}
+	}
+
+	if attr.NumericMetaData != nil && attr.NumericMetaData.LowerRange != nil && attr.NumericMetaData.UpperRange != nil &&
+		*attr.NumericMetaData.LowerRange > *attr.NumericMetaData.UpperRange {
+		problems = append(problems, attributeProblem{
+			fields:  []string{"numeric_meta_data", "lower_range"},
+			summary: "Invalid numeric range",
+			detail:  fmt.Sprintf("lower_range [%g] is greater than upper_range [%g]", *attr.NumericMetaData.LowerRange, *attr.NumericMetaData.UpperRange),
+		})
+	}
+
+	return problems
+}
+
+// Returns an empty string when the default value matches the attribute type, otherwise the reason it does not.
+// The default value is parsed exactly the way it is sent to BioT (see api.ParseDefaultValue).
+func checkDefaultValue(spec attributeTypeSpec, attr api.BaseAttribute, defaultValue string) string {
+	return checkAttributeValue(spec, attr, defaultValue, "default value")
+}
+
+// CheckAttributeValue checks the value of an attribute of an entity (a JSON string) against the attribute of its
+// template, the same way as default values. Returns an empty string when it matches, otherwise the reason it does not.
+func CheckAttributeValue(attr api.BaseAttribute, value string) string {
+	spec, knownType := attributeTypes[attr.Type]
+	if !knownType {
+		return ""
+	}
+	return checkAttributeValue(spec, attr, value, "value")
+}
+
+// valueName is how the value is called in the messages, e.g. "default value".
+func checkAttributeValue(spec attributeTypeSpec, attr api.BaseAttribute, rawValue string, valueName string) string {
+	value := api.ParseDefaultValue(rawValue)
+
+	selectableNames := map[string]bool{}
+	for _, selectableValue := range attr.SelectableValues {
+		selectableNames[selectableValue.Name] = true
+	}
+
+	switch spec.defaultValue {
+	case defaultValueString:
+		name, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("An attribute of type %s requires a string %s, got %s. Use jsonencode(\"...\") for strings that look like numbers or JSON.", attr.Type, valueName, rawValue)
+		}
+		if spec.allowedValuesCatalog != nil && len(selectableNames) > 0 && !selectableNames[name] {
+			return fmt.Sprintf("%s [%s] is not one of the attribute allowed_values", capitalize(valueName), name)
+		}
+	case defaultValueInteger:
+		if number, ok := value.(float64); !ok || number != float64(int64(number)) {
+			return fmt.Sprintf("An attribute of type %s requires an integer %s, got %s", attr.Type, valueName, rawValue)
+		}
+	case defaultValueNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Sprintf("An attribute of type %s requires a numeric %s, got %s", attr.Type, valueName, rawValue)
+		}
+	case defaultValueBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("An attribute of type %s requires a boolean %s (true / false), got %s", attr.Type, valueName, rawValue)
+		}
+	case defaultValueSelectableName:
+		name, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("An attribute of type %s requires the name of one of its selectable_values as %s, got %s", attr.Type, valueName, rawValue)
+		}
+		if len(selectableNames) > 0 && !selectableNames[name] {
+			return fmt.Sprintf("%s [%s] is not one of the attribute selectable_values", capitalize(valueName), name)
+		}
+	case defaultValueSelectableNames:
+		names, ok := value.([]interface{})
+		if !ok {
+			return fmt.Sprintf("An attribute of type %s requires a list of selectable value names as %s (e.g. jsonencode([\"a\", \"b\"])), got %s", attr.Type, valueName, rawValue)
+		}
+		for _, item := range names {
+			name, ok := item.(string)
+			if !ok || (len(selectableNames) > 0 && !selectableNames[name]) {
+				return fmt.Sprintf("%s item [%v] is not one of the attribute selectable_values", capitalize(valueName), item)
+			}
+		}
+	}
+
+	return ""
+}
+
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+func attributeTypesSupporting(supports func(attributeTypeSpec) bool) []string {
+	result := []string{}
+	for _, attributeType := range sortedAttributeTypes() {
+		if supports(attributeTypes[attributeType]) {
+			result = append(result, attributeType)
+		}
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+type wantProblem struct {
+	summary string
+	warning bool
+}
+
+func TestCheckAttribute(t *testing.T) {
+	regex := func(regex string) *api.Validation { return &api.Validation{Regex: &regex} }
+	one, two := 1.0, 2.0
+
+	tests := []struct {
+		name string
+		attr api.BaseAttribute
+		want []wantProblem
+	}{
+		{
+			name: "regex that compiles",
+			attr: api.BaseAttribute{Name: "code", Type: "LABEL", Validation: regex(`^[a-z]+$`)},
+		},
+		{
+			name: "regex with Java features is only a warning",
+			attr: api.BaseAttribute{Name: "code", Type: "LABEL", Validation: regex(`^(?!admin)[a-z]+$`)},
+			want: []wantProblem{{summary: "Unverified validation regex", warning: true}},
+		},
+		{
+			name: "known time zones",
+			attr: api.BaseAttribute{Name: "zone", Type: "TIMEZONE", SelectableValues: []api.SelectableValue{{Name: "Europe/London"}, {Name: "Asia/Jerusalem"}}},
+		},
+		{
+			name: "unknown time zone is only a warning",
+			attr: api.BaseAttribute{Name: "zone", Type: "TIMEZONE", SelectableValues: []api.SelectableValue{{Name: "Mars/Olympus"}}},
+			want: []wantProblem{{summary: "Unknown allowed value", warning: true}},
+		},
+		{
+			name: "locales in both forms",
+			attr: api.BaseAttribute{Name: "language", Type: "LOCALE", SelectableValues: []api.SelectableValue{{Name: "en-US"}, {Name: "he_IL"}}},
+		},
+		{
+			name: "min greater than max",
+			attr: api.BaseAttribute{Name: "weight", Type: "DECIMAL", Validation: &api.Validation{Min: &two, Max: &one}},
+			want: []wantProblem{{summary: "Invalid validation range"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := checkAttribute(test.attr)
+			if len(problems) != len(test.want) {
+				t.Fatalf("got problems %+v, want %+v", problems, test.want)
+			}
+			for i, want := range test.want {
+				if problems[i].summary != want.summary || problems[i].warning != want.warning {
+					t.Errorf("problem %d: got %+v, want %+v", i, problems[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCategoryConfig(t *testing.T) {
+	category := func(name string) BaseTerraformAttribute {
+		return BaseTerraformAttribute{Category: types.ObjectValueMust(categoryAttributeTypes, map[string]attr.Value{
+			"name":         types.StringValue(name),
+			"display_name": types.StringNull(),
+		})}
+	}
+
+	tests := []struct {
+		name       string
+		attribute  BaseTerraformAttribute
+		entityType types.String
+		want       []wantProblem
+	}{
+		{name: "regular", attribute: category("REGULAR"), entityType: types.StringValue("caregiver")},
+		{name: "supported by the entity type", attribute: category("MEASUREMENT"), entityType: types.StringValue("device")},
+		{
+			name:       "not known for the entity type is only a warning",
+			attribute:  category("MEASUREMENT"),
+			entityType: types.StringValue("caregiver"),
+			want:       []wantProblem{{summary: "Unsupported category", warning: true}},
+		},
+		{name: "unknown category is left to the schema", attribute: category("CUSTOM"), entityType: types.StringValue("caregiver")},
+		{name: "entity type not configured", attribute: category("MEASUREMENT"), entityType: types.StringNull()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := checkCategoryConfig(test.attribute, test.entityType)
+			if len(problems) != len(test.want) {
+				t.Fatalf("got problems %+v, want %+v", problems, test.want)
+			}
+			for i, want := range test.want {
+				if problems[i].summary != want.summary || problems[i].warning != want.warning {
+					t.Errorf("problem %d: got %+v, want %+v", i, problems[i], want)
+				}
+			}
+		})
+	}
+}
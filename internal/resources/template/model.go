@@ -1,10 +1,32 @@
 package template
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type TerraformTemplate struct {
+	TerraformTemplateDefinition
+
+	// Provider-only settings, never sent to BioT.
+	ValidateOnPlan     types.Bool `tfsdk:"validate_on_plan"`
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	ForceDelete        types.Bool `tfsdk:"force_delete"`
+	AdoptExisting      types.Bool `tfsdk:"adopt_existing"`
+	FailOnDrift        types.Bool `tfsdk:"fail_on_drift"`
+
+	WaitForConsistency     types.Bool         `tfsdk:"wait_for_consistency"`
+	WaitForAnalyticsDbSync types.Bool         `tfsdk:"wait_for_analytics_db_sync"`
+	Timeouts               *TerraformTimeouts `tfsdk:"timeouts"`
+}
+
+type TerraformTimeouts struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+}
+
+// The fields of a template, shared by biot_template and the templates of biot_template_set.
+type TerraformTemplateDefinition struct {
 	ID                       types.String                       `tfsdk:"id"`
 	Name                     types.String                       `tfsdk:"name"`
 	DisplayName              types.String                       `tfsdk:"display_name"`
@@ -13,24 +35,36 @@ type TerraformTemplate struct {
 	EntityTypeName           types.String                       `tfsdk:"entity_type"`
 	AnalyticsDbConfiguration *TerraformAnalyticsDbConfiguration `tfsdk:"analytics_db_configuration"`
 	ParentTemplateID         types.String                       `tfsdk:"parent_template_id"`
-	BuiltInAttributes        []TerraformBuiltinAttribute               `tfsdk:"builtin_attributes"`
-	CustomAttributes         []TerraformCustomAttribute               `tfsdk:"custom_attributes"`
-	TemplateAttributes       []TerraformTemplateAttribute       `tfsdk:"template_attributes"`
+	// Computed TerraformParentTemplate, an object so it can be unknown until the template is created.
+	ParentTemplate     types.Object                          `tfsdk:"parent_template"`
+	BuiltInAttributes  map[string]TerraformBuiltinAttribute  `tfsdk:"builtin_attributes"`
+	CustomAttributes   map[string]TerraformCustomAttribute   `tfsdk:"custom_attributes"`
+	TemplateAttributes map[string]TerraformTemplateAttribute `tfsdk:"template_attributes"`
+
+	// Provider-only setting, never sent to BioT.
+	BuiltinAttributesManagement types.String `tfsdk:"builtin_attributes_management"`
 }
 
 type BaseTerraformAttribute struct {
-	Name                     types.String                       `tfsdk:"name"`
-	BasePath                 types.String                       `tfsdk:"base_path"`
-	ID                       types.String                       `tfsdk:"id"`
-	DisplayName              types.String                       `tfsdk:"display_name"`
-	Phi                      types.Bool                         `tfsdk:"phi"`
-	ReferenceConfiguration   *TerraformReferenceConfiguration   `tfsdk:"reference_configuration"`
-	LinkConfiguration        *TerraformLinkConfiguration        `tfsdk:"link_configuration"`
-	Validation               *TerraformValidation               `tfsdk:"validation"`
-	NumericMetaData          *TerraformNumericMetaData          `tfsdk:"numeric_meta_data"`
-	Type                     types.String                       `tfsdk:"type"`
-	Category                 types.String                       `tfsdk:"category"`
-	SelectableValues         []TerraformSelectableValue         `tfsdk:"selectable_values"`
+	// The attributes are keyed by name in the schema, Name is populated from the map key.
+	Name                   types.String                     `tfsdk:"-"`
+	BasePath               types.String                     `tfsdk:"base_path"`
+	ID                     types.String                     `tfsdk:"id"`
+	DisplayName            types.String                     `tfsdk:"display_name"`
+	Phi                    types.Bool                       `tfsdk:"phi"`
+	ReferenceConfiguration *TerraformReferenceConfiguration `tfsdk:"reference_configuration"`
+	LinkConfiguration      *TerraformLinkConfiguration      `tfsdk:"link_configuration"`
+	Validation             *TerraformValidation             `tfsdk:"validation"`
+	NumericMetaData        *TerraformNumericMetaData        `tfsdk:"numeric_meta_data"`
+	Type                   types.String                     `tfsdk:"type"`
+	// TerraformCategory, an object so it can be unknown until BioT assigns the default category.
+	Category         types.Object               `tfsdk:"category"`
+	SelectableValues []TerraformSelectableValue `tfsdk:"selectable_values"`
+	AllowedValues    []types.String             `tfsdk:"allowed_values"`
+	// Computed TerraformValidationMetadata, an object so it can be unknown until the attribute is created.
+	ValidationMetadata types.Object `tfsdk:"validation_metadata"`
+	// Provider-only, never sent to BioT.
+	PreviousNames []types.String `tfsdk:"previous_names"`
 }
 
 type TerraformBuiltinAttribute struct {
@@ -62,11 +96,28 @@ type TerraformParentTemplate struct {
 	Name        types.String `tfsdk:"name"`
 }
 
+var parentTemplateAttributeTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"display_name": types.StringType,
+	"name":         types.StringType,
+}
+
+type TerraformCategory struct {
+	Name        types.String `tfsdk:"name"`
+	DisplayName types.String `tfsdk:"display_name"`
+}
+
+var categoryAttributeTypes = map[string]attr.Type{
+	"name":         types.StringType,
+	"display_name": types.StringType,
+}
+
 type TerraformReferenceConfiguration struct {
 	Uniquely                           types.Bool     `tfsdk:"uniquely"`
 	ReferencedSideAttributeName        types.String   `tfsdk:"referenced_side_attribute_name"`
 	ReferencedSideAttributeDisplayName types.String   `tfsdk:"referenced_side_attribute_display_name"`
-	ValidTemplatesToReference          []types.String `tfsdk:"valid_templates_to_reference"`
+	ValidTemplatesToReference          types.List     `tfsdk:"valid_templates_to_reference"`
+	ValidTemplateNames                 []types.String `tfsdk:"valid_template_names"`
 	EntityType                         types.String   `tfsdk:"entity_type"`
 }
 
@@ -74,13 +125,15 @@ type TerraformLinkConfiguration struct {
 	EntityTypeName types.String `tfsdk:"entity_type_name"`
 	TemplateID     types.String `tfsdk:"template_id"`
 	AttributeID    types.String `tfsdk:"attribute_id"`
+	TemplateName   types.String `tfsdk:"template_name"`
+	AttributeName  types.String `tfsdk:"attribute_name"`
 }
 
 type TerraformValidation struct {
 	Mandatory    types.Bool   `tfsdk:"mandatory"`
 	DefaultValue types.String `tfsdk:"default_value"`
-	Min          types.Number  `tfsdk:"min"`
-	Max          types.Number  `tfsdk:"max"`
+	Min          types.Number `tfsdk:"min"`
+	Max          types.Number `tfsdk:"max"`
 	Regex        types.String `tfsdk:"regex"`
 }
 
@@ -90,10 +143,16 @@ type TerraformValidationMetadata struct {
 	PhiReadOnly       types.Bool `tfsdk:"phi_read_only"`
 }
 
+var validationMetadataAttributeTypes = map[string]attr.Type{
+	"mandatory_read_only": types.BoolType,
+	"system_mandatory":    types.BoolType,
+	"phi_read_only":       types.BoolType,
+}
+
 type TerraformNumericMetaData struct {
 	Units      types.String `tfsdk:"units"`
-	UpperRange types.Number  `tfsdk:"upper_range"`
-	LowerRange types.Number  `tfsdk:"lower_range"`
+	UpperRange types.Number `tfsdk:"upper_range"`
+	LowerRange types.Number `tfsdk:"lower_range"`
 	SubType    types.String `tfsdk:"sub_type"`
 }
 
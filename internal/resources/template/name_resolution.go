@@ -0,0 +1,194 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+// Looks up templates by entity type + name or by ID, each template is fetched once per operation.
+type templateResolver struct {
+	client    *api.APIClient
+	byName    map[string]api.TemplateResponse
+	byID      map[string]api.TemplateResponse
+	byNameErr map[string]error
+}
+
+func newTemplateResolver(client *api.APIClient) *templateResolver {
+	return &templateResolver{
+		client:    client,
+		byName:    map[string]api.TemplateResponse{},
+		byID:      map[string]api.TemplateResponse{},
+		byNameErr: map[string]error{},
+	}
+}
+
+func (t *templateResolver) templateByName(ctx context.Context, entityType string, name string) (api.TemplateResponse, error) {
+	key := entityType + "/" + name
+	if template, ok := t.byName[key]; ok {
+		return template, nil
+	}
+	if err, ok := t.byNameErr[key]; ok {
+		return api.TemplateResponse{}, err
+	}
+
+	template, err := t.client.GetTemplateByTypeAndName(ctx, entityType, name)
+	if err != nil {
+		t.byNameErr[key] = err
+		return api.TemplateResponse{}, err
+	}
+
+	t.byName[key] = template
+	t.byID[template.ID] = template
+	return template, nil
+}
+
+func (t *templateResolver) templateByID(ctx context.Context, id string) (api.TemplateResponse, error) {
+	if template, ok := t.byID[id]; ok {
+		return template, nil
+	}
+
+	template, err := t.client.GetTemplate(ctx, id)
+	if err != nil {
+		return api.TemplateResponse{}, err
+	}
+
+	t.byID[id] = template
+	return template, nil
+}
+
+// Resolves the name based references of the attributes (link_configuration.template_name / attribute_name and
+// reference_configuration.valid_template_names) to the IDs BioT expects, since IDs differ between environments.
+// IDs that are already known (configured, or kept from state while the names did not change) are not looked up again.
+// templatePath is the path of the template object, used for the errors (path.Empty() for biot_template).
+func resolveAttributeReferences(ctx context.Context, client *api.APIClient, templatePath path.Path, template *TerraformTemplate) diag.Diagnostics {
+	var diags diag.Diagnostics
+	resolver := newTemplateResolver(client)
+
+	forEachAttribute(TerraformTemplate{}, template, func(collection string, name string, _ *BaseTerraformAttribute, attribute *BaseTerraformAttribute) {
+		diags.Append(resolver.resolveAttribute(ctx, templatePath.AtName(collection).AtMapKey(name), attribute)...)
+	})
+
+	return diags
+}
+
+// Same as resolveAttributeReferences, for a single attribute (biot_template_attribute).
+func resolveSingleAttributeReferences(ctx context.Context, client *api.APIClient, attributePath path.Path, attribute *BaseTerraformAttribute) diag.Diagnostics {
+	return newTemplateResolver(client).resolveAttribute(ctx, attributePath, attribute)
+}
+
+func (t *templateResolver) resolveAttribute(ctx context.Context, attributePath path.Path, attribute *BaseTerraformAttribute) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(t.resolveLinkConfiguration(ctx, attributePath.AtName("link_configuration"), attribute.LinkConfiguration)...)
+	diags.Append(t.resolveReferenceConfiguration(ctx, attributePath.AtName("reference_configuration"), attribute.ReferenceConfiguration)...)
+	return diags
+}
+
+func (t *templateResolver) resolveLinkConfiguration(ctx context.Context, linkPath path.Path, link *TerraformLinkConfiguration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if link == nil {
+		return diags
+	}
+
+	if isKnownString(link.TemplateName) && !isKnownString(link.TemplateID) {
+		template, err := t.templateByName(ctx, link.EntityTypeName.ValueString(), link.TemplateName.ValueString())
+		if err != nil {
+			diags.AddAttributeError(linkPath.AtName("template_name"), "Failed to resolve template_name", fmt.Sprintf(
+				"Template [%s] of entity type [%s] was not found: %s", link.TemplateName.ValueString(), link.EntityTypeName.ValueString(), err,
+			))
+			return diags
+		}
+		link.TemplateID = types.StringValue(template.ID)
+	}
+
+	if isKnownString(link.AttributeName) && !isKnownString(link.AttributeID) {
+		if !isKnownString(link.TemplateID) {
+			diags.AddAttributeError(linkPath.AtName("attribute_name"), "Failed to resolve attribute_name", "attribute_name requires template_id or template_name")
+			return diags
+		}
+
+		template, err := t.templateByID(ctx, link.TemplateID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(linkPath.AtName("attribute_name"), "Failed to resolve attribute_name", fmt.Sprintf(
+				"Failed to read the linked template [%s]: %s", link.TemplateID.ValueString(), err,
+			))
+			return diags
+		}
+
+		attributeID, found := findTemplateAttributeID(template, link.AttributeName.ValueString())
+		if !found {
+			diags.AddAttributeError(linkPath.AtName("attribute_name"), "Failed to resolve attribute_name", fmt.Sprintf(
+				"Template [%s] does not have an attribute named [%s]", template.Name, link.AttributeName.ValueString(),
+			))
+			return diags
+		}
+		link.AttributeID = types.StringValue(attributeID)
+	}
+
+	return diags
+}
+
+func (t *templateResolver) resolveReferenceConfiguration(ctx context.Context, referencePath path.Path, reference *TerraformReferenceConfiguration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if reference == nil || len(reference.ValidTemplateNames) == 0 {
+		return diags
+	}
+	if !reference.ValidTemplatesToReference.IsNull() && !reference.ValidTemplatesToReference.IsUnknown() {
+		return diags
+	}
+
+	ids := []attr.Value{}
+	for i, name := range reference.ValidTemplateNames {
+		if !isKnownString(name) {
+			continue
+		}
+
+		template, err := t.templateByName(ctx, reference.EntityType.ValueString(), name.ValueString())
+		if err != nil {
+			diags.AddAttributeError(referencePath.AtName("valid_template_names").AtListIndex(i), "Failed to resolve valid_template_names", fmt.Sprintf(
+				"Template [%s] of entity type [%s] was not found: %s", name.ValueString(), reference.EntityType.ValueString(), err,
+			))
+			continue
+		}
+		ids = append(ids, types.StringValue(template.ID))
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	resolved, listDiags := types.ListValue(types.StringType, ids)
+	diags.Append(listDiags...)
+	reference.ValidTemplatesToReference = resolved
+
+	return diags
+}
+
+func findTemplateAttributeID(template api.TemplateResponse, name string) (string, bool) {
+	for _, attribute := range template.BuiltInAttributes {
+		if attribute.Name == name {
+			return attribute.ID, true
+		}
+	}
+	for _, attribute := range template.CustomAttributes {
+		if attribute.Name == name {
+			return attribute.ID, true
+		}
+	}
+	for _, attribute := range template.TemplateAttributes {
+		if attribute.Name == name {
+			return attribute.ID, true
+		}
+	}
+	return "", false
+}
+
+func isKnownString(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown() && value.ValueString() != ""
+}
@@ -0,0 +1,35 @@
+package template
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+// The raw template document of the last GET / create / update response.
+// Used as the base of the next update, so server fields the provider does not model are not reset.
+const templateDocumentPrivateKey = "template_document"
+
+// Implemented by the Private field of the resource requests (the framework type is internal).
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// Implemented by the Private field of the resource responses.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func saveTemplateDocument(ctx context.Context, private privateStateSetter, response api.TemplateResponse) diag.Diagnostics {
+	if len(response.Raw) == 0 {
+		return nil
+	}
+
+	return private.SetKey(ctx, templateDocumentPrivateKey, response.Raw)
+}
+
+func loadTemplateDocument(ctx context.Context, private privateStateGetter) ([]byte, diag.Diagnostics) {
+	return private.GetKey(ctx, templateDocumentPrivateKey)
+}
@@ -0,0 +1,184 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+// The attributes of every collection, as BaseTerraformAttribute keyed by attribute name.
+func baseAttributesByCollection(template TerraformTemplate) map[string]map[string]BaseTerraformAttribute {
+	result := map[string]map[string]BaseTerraformAttribute{
+		"builtin_attributes":  {},
+		"custom_attributes":   {},
+		"template_attributes": {},
+	}
+	for name, attribute := range template.BuiltInAttributes {
+		result["builtin_attributes"][name] = attribute.BaseTerraformAttribute
+	}
+	for name, attribute := range template.CustomAttributes {
+		result["custom_attributes"][name] = attribute.BaseTerraformAttribute
+	}
+	for name, attribute := range template.TemplateAttributes {
+		result["template_attributes"][name] = attribute.BaseTerraformAttribute
+	}
+	return result
+}
+
+// Same as baseAttributesByCollection, for the template at templatePath of a plan. Unlike plan.Get, it does not fail
+// when values are unknown (e.g. computed collections on create, or values that depend on other resources):
+// unknown collections, and attributes that contain unknown nested objects, are skipped.
+func knownPlannedAttributes(ctx context.Context, plan tfsdk.Plan, templatePath path.Path) map[string]map[string]BaseTerraformAttribute {
+	result := map[string]map[string]BaseTerraformAttribute{
+		"builtin_attributes":  {},
+		"custom_attributes":   {},
+		"template_attributes": {},
+	}
+
+	for _, collection := range attributeCollections {
+		var attributes types.Map
+		if plan.GetAttribute(ctx, templatePath.AtName(collection), &attributes).HasError() || attributes.IsNull() || attributes.IsUnknown() {
+			continue
+		}
+
+		for name, element := range attributes.Elements() {
+			object, ok := element.(types.Object)
+			if !ok || object.IsUnknown() {
+				continue
+			}
+
+			attribute, diags := objectAsBaseAttribute(ctx, collection, object)
+			if diags.HasError() {
+				tflog.Debug(ctx, "Skipping planned attribute with unknown values", map[string]interface{}{
+					"collection": collection,
+					"attribute":  name,
+				})
+				continue
+			}
+			result[collection][name] = attribute
+		}
+	}
+
+	return result
+}
+
+// The element types of the collections differ, each is read into its own model.
+func objectAsBaseAttribute(ctx context.Context, collection string, object types.Object) (BaseTerraformAttribute, diag.Diagnostics) {
+	options := basetypes.ObjectAsOptions{}
+
+	switch collection {
+	case "builtin_attributes":
+		var attribute TerraformBuiltinAttribute
+		diags := object.As(ctx, &attribute, options)
+		return attribute.BaseTerraformAttribute, diags
+	case "custom_attributes":
+		var attribute TerraformCustomAttribute
+		diags := object.As(ctx, &attribute, options)
+		return attribute.BaseTerraformAttribute, diags
+	default:
+		var attribute TerraformTemplateAttribute
+		diags := object.As(ctx, &attribute, options)
+		return attribute.BaseTerraformAttribute, diags
+	}
+}
+
+// Warns when an update removes selectable values that are still used by entities of the template.
+// Removing a selectable value orphans the entity values that reference it.
+// The check is best effort, it is skipped when the entities can not be searched.
+func (r *BiotTemplateResource) warnAboutRemovedSelectableValuesInUse(ctx context.Context, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var state TerraformTemplate
+	if req.State.Get(ctx, &state).HasError() {
+		return diags
+	}
+
+	// Planned attributes with values that are only known after apply are not compared.
+	plannedAttributes := knownPlannedAttributes(ctx, req.Plan, path.Empty())
+	stateAttributesByCollection := baseAttributesByCollection(state)
+
+	for _, collection := range attributeCollections {
+		stateAttributes := stateAttributesByCollection[collection]
+		for _, name := range utils.SortedKeys(stateAttributes) {
+			stateAttribute := stateAttributes[name]
+			if len(stateAttribute.SelectableValues) == 0 {
+				continue
+			}
+
+			// The attribute is matched by ID, so renamed attributes (previous_names) are compared too.
+			// Attributes that are removed altogether are not checked here.
+			plannedName, plannedAttribute, found := findAttributeByID(plannedAttributes[collection], stateAttribute.ID.ValueString())
+			if !found {
+				continue
+			}
+
+			plannedIDs := map[string]bool{}
+			for _, value := range plannedAttribute.SelectableValues {
+				if value.ID.IsUnknown() {
+					continue
+				}
+				plannedIDs[value.ID.ValueString()] = true
+			}
+
+			inUse := []string{}
+			for _, value := range stateAttribute.SelectableValues {
+				if value.ID.IsNull() || plannedIDs[value.ID.ValueString()] {
+					continue
+				}
+
+				count, err := r.client.CountEntities(ctx, state.EntityTypeName.ValueString(), map[string]interface{}{
+					"_template.id": map[string]interface{}{"in": []string{state.ID.ValueString()}},
+					name:           map[string]interface{}{"in": []string{value.Name.ValueString()}},
+				})
+				if err != nil {
+					tflog.Warn(ctx, "Failed to check if a removed selectable value is in use", map[string]interface{}{
+						"attribute":        name,
+						"selectable_value": value.Name.ValueString(),
+						"error":            err.Error(),
+					})
+					continue
+				}
+
+				if count > 0 {
+					inUse = append(inUse, fmt.Sprintf("%s (id: %s, used by %d entities)", value.Name.ValueString(), value.ID.ValueString(), count))
+				}
+			}
+
+			if len(inUse) > 0 {
+				diags.AddAttributeWarning(
+					path.Root(collection).AtMapKey(plannedName).AtName("selectable_values"),
+					"Removed selectable values are still in use",
+					fmt.Sprintf(
+						"The following selectable values of attribute [%s] are removed, but entities still reference them:\n  - %s\n\n"+
+							"The values stored in these entities will be orphaned. To rename a value instead, keep its id or its display_name.",
+						name, strings.Join(inUse, "\n  - "),
+					),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+func findAttributeByID(attributes map[string]BaseTerraformAttribute, id string) (string, BaseTerraformAttribute, bool) {
+	if id == "" {
+		return "", BaseTerraformAttribute{}, false
+	}
+	for name, attribute := range attributes {
+		if attribute.ID.ValueString() == id {
+			return name, attribute, true
+		}
+	}
+	return "", BaseTerraformAttribute{}, false
+}
@@ -0,0 +1,133 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// The upgraders work on the raw JSON state instead of a prior schema, so they keep working when attributes
+// are added to the current schema (missing attributes are set to null).
+func (r *BiotTemplateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(req, resp, upgradeTemplateStateV0ToV1, upgradeTemplateStateV1ToV2)
+			},
+		},
+		1: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(req, resp, upgradeTemplateStateV1ToV2)
+			},
+		},
+	}
+}
+
+func upgradeRawState(req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, upgraders ...func(map[string]interface{}) error) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to upgrade template state", "The prior state is not available as JSON")
+		return
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade template state", fmt.Sprintf("Failed to parse the prior state: %s", err))
+		return
+	}
+
+	for _, upgrade := range upgraders {
+		if err := upgrade(state); err != nil {
+			resp.Diagnostics.AddError("Unable to upgrade template state", err.Error())
+			return
+		}
+	}
+
+	upgradedState, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade template state", fmt.Sprintf("Failed to encode the upgraded state: %s", err))
+		return
+	}
+
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgradedState}
+}
+
+// Version 0 stored the attribute collections as sets (JSON arrays), version 1 stores them as maps keyed by
+// the attribute name, and the nested "name" attribute is removed.
+func upgradeTemplateStateV0ToV1(state map[string]interface{}) error {
+	for _, collection := range attributeCollections {
+		if state[collection] == nil {
+			continue
+		}
+
+		attributes, ok := state[collection].([]interface{})
+		if !ok {
+			return fmt.Errorf("expected %s to be a list in the prior state, got %T", collection, state[collection])
+		}
+
+		attributesByName := make(map[string]interface{}, len(attributes))
+		for _, attribute := range attributes {
+			attributeObject, ok := attribute.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("expected the elements of %s to be objects in the prior state, got %T", collection, attribute)
+			}
+
+			name, ok := attributeObject["name"].(string)
+			if !ok || name == "" {
+				return fmt.Errorf("found an element without a name in %s", collection)
+			}
+			if _, exists := attributesByName[name]; exists {
+				return fmt.Errorf("attribute name [%s] appears more than once in %s", name, collection)
+			}
+
+			delete(attributeObject, "name")
+			attributesByName[name] = attributeObject
+		}
+
+		state[collection] = attributesByName
+	}
+
+	return nil
+}
+
+// Version 1 stored the category name as a string, version 2 stores the category as an object. The display name is
+// not known yet, it is read from BioT on the next refresh.
+// States written before builtin_attributes_management was added get its default, so the upgrade does not plan a change.
+func upgradeTemplateStateV1ToV2(state map[string]interface{}) error {
+	if state["builtin_attributes_management"] == nil {
+		state["builtin_attributes_management"] = builtinAttributesManagementAll
+	}
+
+	for _, collection := range attributeCollections {
+		if state[collection] == nil {
+			continue
+		}
+
+		attributes, ok := state[collection].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected %s to be a map in the prior state, got %T", collection, state[collection])
+		}
+
+		for name, attribute := range attributes {
+			attributeObject, ok := attribute.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("expected attribute [%s] of %s to be an object in the prior state, got %T", name, collection, attribute)
+			}
+
+			switch category := attributeObject["category"].(type) {
+			case nil:
+			case string:
+				attributeObject["category"] = map[string]interface{}{
+					"name":         category,
+					"display_name": nil,
+				}
+			default:
+				return fmt.Errorf("expected the category of attribute [%s] of %s to be a string in the prior state, got %T", name, collection, category)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package template
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decodeState(t *testing.T, state string) map[string]interface{} {
+	t.Helper()
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(state), &decoded); err != nil {
+		t.Fatalf("invalid test state: %s", err)
+	}
+	return decoded
+}
+
+func TestUpgradeTemplateStateV0ToV1(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "attribute lists become maps keyed by name",
+			state: `{"name": "device", "custom_attributes": [{"name": "weight", "type": "DECIMAL"}, {"name": "color", "type": "LABEL"}], "template_attributes": []}`,
+			want:  `{"name": "device", "custom_attributes": {"weight": {"type": "DECIMAL"}, "color": {"type": "LABEL"}}, "template_attributes": {}}`,
+		},
+		{
+			name:  "missing collections are left as they are",
+			state: `{"name": "device", "builtin_attributes": null}`,
+			want:  `{"name": "device", "builtin_attributes": null}`,
+		},
+		{
+			name:    "duplicate names",
+			state:   `{"custom_attributes": [{"name": "weight"}, {"name": "weight"}]}`,
+			wantErr: "attribute name [weight] appears more than once in custom_attributes",
+		},
+		{
+			name:    "element without a name",
+			state:   `{"custom_attributes": [{"type": "LABEL"}]}`,
+			wantErr: "found an element without a name in custom_attributes",
+		},
+		{
+			name:    "collection that is not a list",
+			state:   `{"custom_attributes": {"weight": {}}}`,
+			wantErr: "expected custom_attributes to be a list",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := decodeState(t, test.state)
+
+			err := upgradeTemplateStateV0ToV1(state)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := decodeState(t, test.want); !reflect.DeepEqual(state, want) {
+				t.Errorf("upgraded state:\n got: %v\nwant: %v", state, want)
+			}
+		})
+	}
+}
+
+func TestUpgradeTemplateStateV1ToV2(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "category names become objects",
+			state: `{"builtin_attributes_management": "all", "custom_attributes": {"weight": {"category": "MEASUREMENT"}, "color": {"category": null}}}`,
+			want:  `{"builtin_attributes_management": "all", "custom_attributes": {"weight": {"category": {"name": "MEASUREMENT", "display_name": null}}, "color": {"category": null}}}`,
+		},
+		{
+			name:  "builtin_attributes_management gets its default",
+			state: `{"name": "device"}`,
+			want:  `{"name": "device", "builtin_attributes_management": "all"}`,
+		},
+		{
+			name:  "builtin_attributes_management is kept",
+			state: `{"builtin_attributes_management": "declared"}`,
+			want:  `{"builtin_attributes_management": "declared"}`,
+		},
+		{
+			name:    "category that is not a string",
+			state:   `{"builtin_attributes_management": "all", "custom_attributes": {"weight": {"category": 1}}}`,
+			wantErr: "expected the category of attribute [weight] of custom_attributes to be a string",
+		},
+		{
+			name:    "collection that is not a map",
+			state:   `{"builtin_attributes_management": "all", "custom_attributes": []}`,
+			wantErr: "expected custom_attributes to be a map",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := decodeState(t, test.state)
+
+			err := upgradeTemplateStateV1ToV2(state)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if want := decodeState(t, test.want); !reflect.DeepEqual(state, want) {
+				t.Errorf("upgraded state:\n got: %v\nwant: %v", state, want)
+			}
+		})
+	}
+}
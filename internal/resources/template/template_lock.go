@@ -0,0 +1,18 @@
+package template
+
+import (
+	"sync"
+)
+
+// Templates are updated with read-modify-write (the whole template is sent), so concurrent updates of the same
+// template by this provider process, e.g. several biot_template_attribute resources on one template, are serialized.
+// Otherwise the last update would drop the changes of the others.
+var templateLocks sync.Map
+
+// Locks the template and returns the function that unlocks it.
+func lockTemplate(templateID string) func() {
+	value, _ := templateLocks.LoadOrStore(templateID, &sync.Mutex{})
+	mutex := value.(*sync.Mutex)
+	mutex.Lock()
+	return mutex.Unlock
+}
@@ -5,28 +5,28 @@ import (
 
 	"biot.com/terraform-provider-biot-gen2/internal/api"
 	"biot.com/terraform-provider-biot-gen2/internal/utils"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 func mapTemplateResponseToTerrformModel(ctx context.Context, resp api.TemplateResponse) TerraformTemplate {
-	builtInAttrs := []TerraformBuiltinAttribute{}
-
+	// Attributes are keyed by name
+	builtInAttrs := map[string]TerraformBuiltinAttribute{}
 	for _, attr := range resp.BuiltInAttributes {
-		builtInAttrs = append(builtInAttrs, mapBuiltinAttributeResponseToTerraformAttribute(ctx, attr))
+		builtInAttrs[attr.Name] = mapBuiltinAttributeResponseToTerraformAttribute(ctx, attr)
 	}
 
-	customAttrs := []TerraformCustomAttribute{}
+	customAttrs := map[string]TerraformCustomAttribute{}
 	for _, attr := range resp.CustomAttributes {
-		customAttrs = append(customAttrs, mapCustomAttributeResponseToTerraformAttribute(ctx, attr))
+		customAttrs[attr.Name] = mapCustomAttributeResponseToTerraformAttribute(ctx, attr)
 	}
 
-	templateAttrs := []TerraformTemplateAttribute{}
+	templateAttrs := map[string]TerraformTemplateAttribute{}
 	for _, attr := range resp.TemplateAttributes {
-		templateAttrs = append(templateAttrs, mapTemplateAttributeResponseToTerrformAttribute(ctx, attr))
+		templateAttrs[attr.Name] = mapTemplateAttributeResponseToTerrformAttribute(ctx, attr)
 	}
 
-	template := TerraformTemplate{
+	template := TerraformTemplate{TerraformTemplateDefinition: TerraformTemplateDefinition{
 		ID:                  types.StringValue(resp.ID),
 		Name:                types.StringValue(resp.Name),
 		DisplayName:         types.StringValue(resp.DisplayName),
@@ -37,11 +37,12 @@ func mapTemplateResponseToTerrformModel(ctx context.Context, resp api.TemplateRe
 		AnalyticsDbConfiguration: mapToTerraformAnalyticsDbConfiguration(ctx, resp.AnalyticsDbConfiguration),
 
 		ParentTemplateID: mapToTerraformParentTemplateID(ctx, resp.ParentTemplate),
+		ParentTemplate:   mapToTerraformParentTemplate(ctx, resp.ParentTemplate),
 
 		BuiltInAttributes:  builtInAttrs,
 		CustomAttributes:   customAttrs,
 		TemplateAttributes: templateAttrs,
-	}
+	}}
 
 	return template
 }
@@ -74,10 +75,12 @@ func mapAttributeResponseToTerrformAttribute(ctx context.Context, attr api.BaseA
 		Type:                   types.StringValue(attr.Type),
 		Category:               mapToTerraformCategory(ctx, attr.Category),
 		SelectableValues:       mapToTerraformSelectableValues(ctx, attr.Type, attr.SelectableValues),
+		AllowedValues:          mapToTerraformAllowedValues(attr.Type, attr.SelectableValues),
 		ReferenceConfiguration: mapToTerraformReferenceConfiguration(ctx, attr.ReferenceConfiguration),
 		LinkConfiguration:      mapToTerraformLinkConfiguration(ctx, attr.LinkConfiguration),
 		Validation:             mapToTerraformValidation(ctx, attr.Validation),
 		NumericMetaData:        mapToTerraformNumericMetaData(ctx, attr.NumericMetaData),
+		ValidationMetadata:     mapToTerraformValidationMetadata(ctx, attr.ValidationMetadata),
 	}
 }
 
@@ -101,20 +104,23 @@ func mapToTerraformAnalyticsDbConfiguration(ctx context.Context, adbConfiguratio
 	}
 }
 
-func mapToTerraformCategory(ctx context.Context, category *api.Category) basetypes.StringValue {
+func mapToTerraformCategory(ctx context.Context, category *api.Category) types.Object {
 	if category == nil {
-		return types.StringNull()
+		return types.ObjectNull(categoryAttributeTypes)
 	}
 
-	return types.StringValue(category.Name)
+	return types.ObjectValueMust(categoryAttributeTypes, map[string]attr.Value{
+		"name":         types.StringValue(category.Name),
+		"display_name": utils.StringOrNull(category.DisplayName),
+	})
 }
 
 func mapToTerraformSelectableValues(ctx context.Context, attributeType string, selectableValues []api.SelectableValue) []TerraformSelectableValue {
 	// It is important that we do NOT return nil here, otherwise terraform will detect changes where there are none.
 	result := []TerraformSelectableValue{}
 
-	// Timezone and Locale attributes are hard coded VERY LONG array we want to ignore them.
-	if attributeType == "TIMEZONE" || attributeType == "LOCALE" {
+	// Timezone and Locale attributes are hard coded VERY LONG array we want to ignore them (see allowed_values).
+	if hasAllowedValuesCatalog(attributeType) {
 		return []TerraformSelectableValue{}
 	}
 
@@ -129,6 +135,20 @@ func mapToTerraformSelectableValues(ctx context.Context, attributeType string, s
 	return result
 }
 
+// The selectable values of Timezone and Locale attributes. This is the whole catalog when the attribute is not
+// restricted, so it is kept in state only when allowed_values is configured (see keepConfiguredAllowedValues).
+func mapToTerraformAllowedValues(attributeType string, selectableValues []api.SelectableValue) []types.String {
+	if !hasAllowedValuesCatalog(attributeType) {
+		return nil
+	}
+
+	result := []types.String{}
+	for _, sv := range selectableValues {
+		result = append(result, types.StringValue(sv.Name))
+	}
+	return result
+}
+
 func mapToTerraformReferenceConfiguration(ctx context.Context, referenceConfiguration *api.ReferenceConfiguration) *TerraformReferenceConfiguration {
 	if referenceConfiguration == nil {
 		return nil
@@ -138,7 +158,7 @@ func mapToTerraformReferenceConfiguration(ctx context.Context, referenceConfigur
 		Uniquely:                           types.BoolValue(referenceConfiguration.Uniquely),
 		ReferencedSideAttributeName:        types.StringValue(referenceConfiguration.ReferencedSideAttributeName),
 		ReferencedSideAttributeDisplayName: types.StringValue(referenceConfiguration.ReferencedSideAttributeDisplayName),
-		ValidTemplatesToReference:          utils.ConvertStringList(referenceConfiguration.ValidTemplatesToReference),
+		ValidTemplatesToReference:          utils.ConvertStringListValue(referenceConfiguration.ValidTemplatesToReference),
 		EntityType:                         types.StringValue(referenceConfiguration.EntityType),
 	}
 }
@@ -189,6 +209,30 @@ func mapToTerraformParentTemplateID(ctx context.Context, parentTemplate *api.Par
 	return types.StringValue(parentTemplate.ID)
 }
 
+func mapToTerraformParentTemplate(ctx context.Context, parentTemplate *api.ParentTemplate) types.Object {
+	if parentTemplate == nil {
+		return types.ObjectNull(parentTemplateAttributeTypes)
+	}
+
+	return types.ObjectValueMust(parentTemplateAttributeTypes, map[string]attr.Value{
+		"id":           types.StringValue(parentTemplate.ID),
+		"display_name": types.StringValue(parentTemplate.DisplayName),
+		"name":         types.StringValue(parentTemplate.Name),
+	})
+}
+
+func mapToTerraformValidationMetadata(ctx context.Context, validationMetadata *api.ValidationMetadata) types.Object {
+	if validationMetadata == nil {
+		return types.ObjectNull(validationMetadataAttributeTypes)
+	}
+
+	return types.ObjectValueMust(validationMetadataAttributeTypes, map[string]attr.Value{
+		"mandatory_read_only": types.BoolValue(validationMetadata.MandatoryReadOnly),
+		"system_mandatory":    types.BoolValue(validationMetadata.SystemMandatory),
+		"phi_read_only":       types.BoolValue(validationMetadata.PhiReadOnly),
+	})
+}
+
 func mapToTerraformOrganizationSelection(ctx context.Context, organizationSelection *api.OrganizationSelection) *TerraformOrganizationSelection {
 	if organizationSelection == nil || organizationSelection.Configuration == nil {
 		return nil
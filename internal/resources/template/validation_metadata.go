@@ -0,0 +1,73 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+// BioT rejects changing mandatory / phi on attributes it marks as read-only (validation_metadata), reported at plan
+// time instead of failing the apply.
+// templatePath is the path of the template object (path.Empty() for biot_template), plannedAttributes are the known
+// planned attributes (see knownPlannedAttributes).
+func checkReadOnlyAttributeChanges(ctx context.Context, templatePath path.Path, state TerraformTemplate, plannedAttributes map[string]map[string]BaseTerraformAttribute) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	stateAttributesByCollection := baseAttributesByCollection(state)
+
+	for _, collection := range attributeCollections {
+		stateAttributes := stateAttributesByCollection[collection]
+		for _, name := range utils.SortedKeys(stateAttributes) {
+			stateAttribute := stateAttributes[name]
+			if stateAttribute.ValidationMetadata.IsNull() || stateAttribute.ValidationMetadata.IsUnknown() {
+				continue
+			}
+
+			var metadata TerraformValidationMetadata
+			diags.Append(stateAttribute.ValidationMetadata.As(ctx, &metadata, basetypes.ObjectAsOptions{})...)
+			if diags.HasError() {
+				return diags
+			}
+
+			plannedName, plannedAttribute, found := findAttributeByID(plannedAttributes[collection], stateAttribute.ID.ValueString())
+			if !found {
+				continue
+			}
+			attributePath := templatePath.AtName(collection).AtMapKey(plannedName)
+
+			plannedMandatory := mandatoryOf(plannedAttribute)
+			if metadata.MandatoryReadOnly.ValueBool() && isKnownBool(plannedMandatory) && plannedMandatory.ValueBool() != mandatoryOf(stateAttribute).ValueBool() {
+				diags.AddAttributeError(attributePath.AtName("validation").AtName("mandatory"), "Read-only mandatory", fmt.Sprintf(
+					"BioT does not allow changing mandatory on attribute [%s] (system_mandatory: %t). Set it to %t or remove it from the configuration.",
+					plannedName, metadata.SystemMandatory.ValueBool(), mandatoryOf(stateAttribute).ValueBool(),
+				))
+			}
+
+			if metadata.PhiReadOnly.ValueBool() && isKnownBool(plannedAttribute.Phi) && plannedAttribute.Phi.ValueBool() != stateAttribute.Phi.ValueBool() {
+				diags.AddAttributeError(attributePath.AtName("phi"), "Read-only phi", fmt.Sprintf(
+					"BioT does not allow changing phi on attribute [%s]. Set it to %t or remove it from the configuration.",
+					plannedName, stateAttribute.Phi.ValueBool(),
+				))
+			}
+		}
+	}
+
+	return diags
+}
+
+func mandatoryOf(attribute BaseTerraformAttribute) types.Bool {
+	if attribute.Validation == nil {
+		return types.BoolNull()
+	}
+	return attribute.Validation.Mandatory
+}
+
+func isKnownBool(value types.Bool) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
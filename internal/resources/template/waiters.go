@@ -0,0 +1,235 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+const (
+	defaultWriteTimeout = 10 * time.Minute
+	minPollInterval     = 2 * time.Second
+	maxPollInterval     = 15 * time.Second
+)
+
+// Returns the configured timeout of the operation ("create" / "update"), or the default one.
+// The values are validated by the schema.
+func writeTimeout(timeouts *TerraformTimeouts, operation string) time.Duration {
+	if timeouts == nil {
+		return defaultWriteTimeout
+	}
+
+	value := timeouts.Create
+	if operation == "update" {
+		value = timeouts.Update
+	}
+
+	duration, err := time.ParseDuration(value.ValueString())
+	if value.IsNull() || value.IsUnknown() || err != nil {
+		return defaultWriteTimeout
+	}
+	return duration
+}
+
+// Runs the waiters enabled on the template after a create / update, within the timeout of the operation.
+//   - wait_for_consistency: BioT reads can return the previous template for a short while after a write, so
+//     dependent resources would read stale data. GetTemplate is polled until it returns what was sent (sent is the
+//     template the request was mapped from).
+//   - wait_for_analytics_db_sync: the analytics DB columns are created minutes after the write, the sync status is
+//     polled until it is completed.
+//
+// The state is already saved when this runs, a failure on create is only a warning, so the template is not tainted
+// (and replaced) because it was slow to become readable or to sync.
+func (r *BiotTemplateResource) waitAfterWrite(ctx context.Context, plan TerraformTemplate, sent TerraformTemplate, written api.TemplateResponse, operation string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !plan.WaitForConsistency.ValueBool() && !plan.WaitForAnalyticsDbSync.ValueBool() {
+		return diags
+	}
+
+	timeout := writeTimeout(plan.Timeouts, operation)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var waitDiags diag.Diagnostics
+	if plan.WaitForConsistency.ValueBool() {
+		waitDiags.Append(r.waitForConsistency(ctx, sent, written, timeout)...)
+	}
+	if plan.WaitForAnalyticsDbSync.ValueBool() && !waitDiags.HasError() {
+		waitDiags.Append(r.waitForAnalyticsDbSync(ctx, written, timeout)...)
+	}
+
+	for _, d := range waitDiags {
+		if d.Severity() == diag.SeverityError && operation == "create" {
+			diags.AddWarning(d.Summary(), d.Detail()+"\n\nThe template was created and is tracked in the state.")
+			continue
+		}
+		diags.Append(d)
+	}
+	return diags
+}
+
+func (r *BiotTemplateResource) waitForConsistency(ctx context.Context, sent TerraformTemplate, written api.TemplateResponse, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sentPayload, err := json.Marshal(MapTerraformTemplateToUpdateRequest(ctx, sent))
+	if err != nil {
+		diags.AddWarning("Not waiting for the template to be consistent", fmt.Sprintf("Failed to encode the sent template: %s", err))
+		return diags
+	}
+
+	err = pollUntil(ctx, func() (bool, error) {
+		return r.isTemplateConsistent(ctx, written.ID, sentPayload)
+	})
+	if err != nil {
+		diags.AddError("Template is not consistent", fmt.Sprintf(
+			"Template [%s] was written, but reading it did not return the written template within %s: %s", written.Name, timeout, err,
+		))
+	}
+	return diags
+}
+
+// Reports whether reading the template returns every field that was sent.
+func (r *BiotTemplateResource) isTemplateConsistent(ctx context.Context, templateID string, sentPayload []byte) (bool, error) {
+	current, err := r.client.GetTemplate(ctx, templateID)
+	if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+		// A created template may not be readable yet.
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	currentPayload, err := templatePayload(ctx, current)
+	if err != nil {
+		return false, err
+	}
+
+	var sentValue, currentValue interface{}
+	if err := json.Unmarshal(sentPayload, &sentValue); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(currentPayload, &currentValue); err != nil {
+		return false, err
+	}
+
+	consistent := containsSentValues("", sentValue, currentValue)
+	if !consistent {
+		tflog.Debug(ctx, "Template read does not match the sent template yet", map[string]interface{}{
+			"template_id": templateID,
+		})
+	}
+	return consistent, nil
+}
+
+// The modeled fields of the template, in the form they are sent to BioT.
+func templatePayload(ctx context.Context, template api.TemplateResponse) ([]byte, error) {
+	return json.Marshal(MapTerraformTemplateToUpdateRequest(ctx, mapTemplateResponseToTerrformModel(ctx, template)))
+}
+
+// Reports whether current has every value of sent. Values that were not sent (null, or the empty ID of a new
+// attribute) are assigned by BioT and not compared. Attributes and selectable values are matched by name; the read
+// template can have more builtin and template attributes than were sent (BioT adds them), but not more custom
+// attributes or selectable values, so a stale read that still has a deleted one is not consistent.
+func containsSentValues(field string, sent interface{}, current interface{}) bool {
+	if sent == nil || (field == "id" && sent == "") {
+		return true
+	}
+
+	sentObject, sentIsObject := sent.(map[string]interface{})
+	currentObject, currentIsObject := current.(map[string]interface{})
+	if sentIsObject && currentIsObject {
+		for key, value := range sentObject {
+			if !containsSentValues(key, value, currentObject[key]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if _, sentIsArray := sent.([]interface{}); sentIsArray && current == nil {
+		// BioT returns null for empty arrays, e.g. the selectable values of an attribute that has none.
+		current = []interface{}{}
+	}
+
+	sentByName, sentIsNamed := elementsByName(sent)
+	currentByName, currentIsNamed := elementsByName(current)
+	if sentIsNamed && currentIsNamed {
+		if len(currentByName) > len(sentByName) && field != "builtInAttributes" && field != "templateAttributes" {
+			return false
+		}
+		for name, element := range sentByName {
+			if !containsSentValues(name, element, currentByName[name]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(sent, current)
+}
+
+func (r *BiotTemplateResource) waitForAnalyticsDbSync(ctx context.Context, written api.TemplateResponse, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var lastStatus api.AnalyticsDbSyncStatusResponse
+
+	err := pollUntil(ctx, func() (bool, error) {
+		status, err := r.client.GetAnalyticsDbSyncStatus(ctx, written.ID)
+		if err != nil {
+			return false, err
+		}
+		lastStatus = status
+
+		switch status.Status {
+		case api.AnalyticsDbSyncCompleted:
+			return true, nil
+		case api.AnalyticsDbSyncFailed:
+			return false, fmt.Errorf("analytics DB sync failed: %s", status.Message)
+		default:
+			return false, nil
+		}
+	})
+
+	switch {
+	case err == nil:
+	case errors.Is(err, api.SpecificErrorCodes.NotFound):
+		diags.AddWarning(
+			"Analytics DB sync status is not available",
+			"This BioT version does not report the analytics DB sync status, wait_for_analytics_db_sync is ignored.",
+		)
+	case errors.Is(err, context.DeadlineExceeded):
+		diags.AddError("Analytics DB sync did not complete", fmt.Sprintf(
+			"The analytics DB sync of template [%s] did not complete within %s (last status: %s). Increase the timeout in timeouts.",
+			written.Name, timeout, lastStatus.Status,
+		))
+	default:
+		diags.AddError("Analytics DB sync failed", fmt.Sprintf("Failed waiting for the analytics DB sync of template [%s]: %s", written.Name, err))
+	}
+
+	return diags
+}
+
+// Calls check until it reports done or fails, with a growing interval, until the context is done.
+func pollUntil(ctx context.Context, check func() (bool, error)) error {
+	interval := minPollInterval
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxPollInterval)
+	}
+}
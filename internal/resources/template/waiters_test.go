@@ -0,0 +1,100 @@
+package template
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestContainsSentValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		sent    string
+		current string
+		want    bool
+	}{
+		{
+			name:    "same values",
+			sent:    `{"displayName": "Device", "customAttributes": [{"name": "weight", "type": "DECIMAL"}]}`,
+			current: `{"displayName": "Device", "customAttributes": [{"name": "weight", "type": "DECIMAL"}]}`,
+			want:    true,
+		},
+		{
+			name:    "stale value",
+			sent:    `{"displayName": "Device 2"}`,
+			current: `{"displayName": "Device"}`,
+		},
+		{
+			name:    "custom attribute without a category",
+			sent:    `{"customAttributes": [{"id": "a1", "name": "weight"}]}`,
+			current: `{"customAttributes": [{"id": "a1", "name": "weight", "category": "REGULAR"}]}`,
+			want:    true,
+		},
+		{
+			name:    "ID of a new attribute is assigned by BioT",
+			sent:    `{"customAttributes": [{"id": "", "name": "weight"}]}`,
+			current: `{"customAttributes": [{"id": "a1", "name": "weight"}]}`,
+			want:    true,
+		},
+		{
+			name:    "attributes are matched by name",
+			sent:    `{"customAttributes": [{"name": "weight"}, {"name": "height"}]}`,
+			current: `{"customAttributes": [{"name": "height"}, {"name": "weight"}]}`,
+			want:    true,
+		},
+		{
+			name:    "deleted custom attribute is still read",
+			sent:    `{"customAttributes": [{"name": "weight"}]}`,
+			current: `{"customAttributes": [{"name": "weight"}, {"name": "height"}]}`,
+		},
+		{
+			name:    "builtin attributes added by BioT",
+			sent:    `{"builtInAttributes": [{"name": "_name"}]}`,
+			current: `{"builtInAttributes": [{"name": "_name"}, {"name": "_description"}]}`,
+			want:    true,
+		},
+		{
+			name:    "added attribute is not read yet",
+			sent:    `{"customAttributes": [{"name": "weight"}, {"name": "height"}]}`,
+			current: `{"customAttributes": [{"name": "weight"}]}`,
+		},
+		{
+			name:    "empty selectable values are read as null",
+			sent:    `{"customAttributes": [{"name": "weight", "selectableValues": []}]}`,
+			current: `{"customAttributes": [{"name": "weight", "selectableValues": null}]}`,
+			want:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var sent, current interface{}
+			if err := json.Unmarshal([]byte(test.sent), &sent); err != nil {
+				t.Fatalf("invalid sent template: %s", err)
+			}
+			if err := json.Unmarshal([]byte(test.current), &current); err != nil {
+				t.Fatalf("invalid current template: %s", err)
+			}
+
+			if got := containsSentValues("", sent, current); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWriteTimeout(t *testing.T) {
+	timeouts := &TerraformTimeouts{Create: types.StringValue("30s"), Update: types.StringNull()}
+
+	if got := writeTimeout(timeouts, "create"); got != 30*time.Second {
+		t.Errorf("create timeout: got %s, want 30s", got)
+	}
+	if got := writeTimeout(timeouts, "update"); got != defaultWriteTimeout {
+		t.Errorf("update timeout: got %s, want %s", got, defaultWriteTimeout)
+	}
+	if got := writeTimeout(nil, "create"); got != defaultWriteTimeout {
+		t.Errorf("timeout without timeouts: got %s, want %s", got, defaultWriteTimeout)
+	}
+}
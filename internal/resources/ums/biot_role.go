@@ -0,0 +1,232 @@
+package ums
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	biotvalidators "biot.com/terraform-provider-biot-gen2/internal/resources/biot_validators"
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+func NewRoleResource() resource.Resource {
+	return &BiotRoleResource{}
+}
+
+type BiotRoleResource struct {
+	client *api.APIClient
+}
+
+type TerraformRole struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Permissions []types.String `tfsdk:"permissions"`
+}
+
+var _ resource.ResourceWithImportState = &BiotRoleResource{}
+var _ resource.ResourceWithModifyPlan = &BiotRoleResource{}
+
+func (r *BiotRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_role"
+}
+
+func (r *BiotRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A UMS role: a named set of permissions, assigned to users and services with `biot_role_assignment`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"permissions": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The names of the permissions of the role. Checked against the permission catalog (see the `biot_permissions` data source) at plan time.",
+			},
+		},
+	}
+}
+
+// Unknown permissions are reported at plan time, with the closest permission of the catalog.
+// Permissions already in the state were accepted by UMS, so the catalog is read only when permissions are added.
+func (r *BiotRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed, or the provider is not configured yet (e.g. during validate).
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	// Only the permissions that are added are checked, so plans that do not change them do not read the catalog.
+	var planned, current types.Set
+	if req.Plan.GetAttribute(ctx, path.Root("permissions"), &planned).HasError() || planned.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() && req.State.GetAttribute(ctx, path.Root("permissions"), &current).HasError() {
+		return
+	}
+
+	added := []types.String{}
+	for _, element := range planned.Elements() {
+		permission, ok := element.(types.String)
+		if ok && !permission.IsUnknown() && !slices.Contains(current.Elements(), element) {
+			added = append(added, permission)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	catalog, err := r.client.GetPermissions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Permissions are not checked", fmt.Sprintf("Failed to read the permission catalog: %s", err))
+		return
+	}
+
+	names := make([]string, 0, len(catalog.Data))
+	for _, permission := range catalog.Data {
+		names = append(names, permission.Name)
+	}
+	slices.Sort(names)
+
+	permissionValidator := biotvalidators.OneOfWithSuggestion(names)
+	for _, permission := range added {
+		validateResp := &validator.StringResponse{}
+		permissionValidator.ValidateString(ctx, validator.StringRequest{
+			Path:        path.Root("permissions").AtSetValue(permission),
+			ConfigValue: permission,
+		}, validateResp)
+		resp.Diagnostics.Append(validateResp.Diagnostics...)
+	}
+}
+
+func (r *BiotRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformRole
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetRole(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			// The role does not exist in the backend, removing it from local state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read role: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapRoleResponse(response))...)
+}
+
+func (r *BiotRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformRole
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.CreateRole(ctx, roleRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to create role: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapRoleResponse(response))...)
+}
+
+func (r *BiotRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TerraformRole
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.UpdateRole(ctx, state.ID.ValueString(), roleRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to update role: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, mapRoleResponse(response))...)
+}
+
+func (r *BiotRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformRole
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRole(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to delete role: %s", err))
+	}
+}
+
+func (r *BiotRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func roleRequest(plan TerraformRole) api.RoleRequest {
+	permissions := make([]string, 0, len(plan.Permissions))
+	for _, permission := range plan.Permissions {
+		permissions = append(permissions, permission.ValueString())
+	}
+	slices.Sort(permissions)
+
+	return api.RoleRequest{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueStringPointer(),
+		Permissions: permissions,
+	}
+}
+
+func mapRoleResponse(response api.RoleResponse) TerraformRole {
+	permissions := make([]types.String, 0, len(response.Permissions))
+	for _, permission := range response.Permissions {
+		permissions = append(permissions, types.StringValue(permission))
+	}
+
+	return TerraformRole{
+		ID:          types.StringValue(response.ID),
+		Name:        types.StringValue(response.Name),
+		Description: utils.StringOrNullPtr(response.Description),
+		Permissions: permissions,
+	}
+}
@@ -0,0 +1,180 @@
+package ums
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	biotvalidators "biot.com/terraform-provider-biot-gen2/internal/resources/biot_validators"
+)
+
+func NewRoleAssignmentResource() resource.Resource {
+	return &BiotRoleAssignmentResource{}
+}
+
+// Assigns a role to a user or a service. Every change replaces the assignment.
+type BiotRoleAssignmentResource struct {
+	client *api.APIClient
+}
+
+type TerraformRoleAssignment struct {
+	ID            types.String `tfsdk:"id"`
+	RoleID        types.String `tfsdk:"role_id"`
+	PrincipalID   types.String `tfsdk:"principal_id"`
+	PrincipalType types.String `tfsdk:"principal_type"`
+}
+
+var principalTypes = []string{api.PrincipalTypeService, api.PrincipalTypeUser}
+
+var _ resource.ResourceWithImportState = &BiotRoleAssignmentResource{}
+
+func (r *BiotRoleAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_role_assignment"
+}
+
+func (r *BiotRoleAssignmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotRoleAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assigns a `biot_role` to a user (e.g. a `biot_organization_user`) or a service (e.g. a `biot_service`).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the assignment, \"role_id/principal_id\".",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the user or service the role is assigned to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principal_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The type of the principal. One of: %s.", biotvalidators.FormatMarkdownValues(principalTypes)),
+				Validators: []validator.String{
+					biotvalidators.OneOfWithSuggestion(principalTypes),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BiotRoleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformRoleAssignment
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assignments, err := r.client.GetRoleAssignments(ctx, state.RoleID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			// The role does not exist in the backend, removing the assignment from local state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read role assignments: %s", err))
+		return
+	}
+
+	for _, assignment := range assignments.Data {
+		if assignment.PrincipalID == state.PrincipalID.ValueString() {
+			state.PrincipalType = types.StringValue(assignment.PrincipalType)
+			resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			return
+		}
+	}
+
+	// The role was unassigned outside Terraform.
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *BiotRoleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformRoleAssignment
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AssignRole(ctx, plan.RoleID.ValueString(), api.RoleAssignment{
+		PrincipalID:   plan.PrincipalID.ValueString(),
+		PrincipalType: plan.PrincipalType.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to assign role: %s", err))
+		return
+	}
+
+	plan.ID = types.StringValue(roleAssignmentID(plan.RoleID.ValueString(), plan.PrincipalID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// All attributes require replace.
+func (r *BiotRoleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Unexpected update", "Role assignments can not be updated, every change replaces the assignment.")
+}
+
+func (r *BiotRoleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformRoleAssignment
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UnassignRole(ctx, state.RoleID.ValueString(), state.PrincipalID.ValueString())
+	if err != nil && !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to unassign role: %s", err))
+	}
+}
+
+// Import ID format: "role_id/principal_id".
+func (r *BiotRoleAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	roleID, principalID, found := strings.Cut(req.ID, "/")
+	if !found || roleID == "" || principalID == "" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected \"role_id/principal_id\", got [%s]", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), roleID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal_id"), principalID)...)
+}
+
+func roleAssignmentID(roleID string, principalID string) string {
+	return roleID + "/" + principalID
+}
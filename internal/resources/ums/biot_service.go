@@ -0,0 +1,368 @@
+package ums
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+	"biot.com/terraform-provider-biot-gen2/internal/utils"
+)
+
+func NewServiceResource() resource.Resource {
+	return &BiotServiceResource{}
+}
+
+// A UMS service, the kind of principal the provider itself logs in as.
+// The secret key is write-only: it is read from the configuration when it has to be set and never stored, only its
+// SHA-256 is kept in private state, to warn when the configured key is not the one that was applied.
+type BiotServiceResource struct {
+	client *api.APIClient
+}
+
+type TerraformService struct {
+	ID              types.String   `tfsdk:"id"`
+	Name            types.String   `tfsdk:"name"`
+	Description     types.String   `tfsdk:"description"`
+	RoleIDs         []types.String `tfsdk:"role_ids"`
+	SecretKey       types.String   `tfsdk:"secret_key_wo"`
+	RotationTrigger types.String   `tfsdk:"rotation_trigger"`
+}
+
+// The SHA-256 of the secret key that was last applied.
+const appliedSecretKeyHashPrivateKey = "secret_key_sha256"
+
+var _ resource.ResourceWithImportState = &BiotServiceResource{}
+var _ resource.ResourceWithModifyPlan = &BiotServiceResource{}
+
+func (r *BiotServiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "biot_service"
+}
+
+func (r *BiotServiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BiotServiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A UMS service (e.g. for a plugin or an integration) and the roles assigned to it. " +
+			"The secret key is passed with the write-only `secret_key_wo`, typically from the `biot_service_secret` ephemeral resource, " +
+			"and is never stored in the plan or state. Requires Terraform 1.11 or later.\n\n" +
+			"The key is applied on create and when `rotation_trigger` changes. `biot_service_secret` returns the same key until its " +
+			"`rotation_trigger` changes, so give it the same `rotation_trigger` (and `service_name`) as the service. " +
+			"A plan warns when `secret_key_wo` is not the key that was applied.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the service, used with the secret key to log in.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"role_ids": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the roles (see `biot_role`) assigned to the service. Do not also assign them with `biot_role_assignment`.",
+			},
+			"secret_key_wo": schema.StringAttribute{
+				Required:  true,
+				WriteOnly: true,
+				Sensitive: true,
+				MarkdownDescription: "The secret key of the service. Write-only: set on create and whenever `rotation_trigger` changes, " +
+					"a different value is ignored otherwise (with a warning).",
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Any value, e.g. a date. Changing it sets the service secret key to the current `secret_key_wo`; the previous secret key stops working. " +
+					"Pass the same value to `biot_service_secret`.",
+			},
+		},
+	}
+}
+
+func (r *BiotServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TerraformService
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.GetService(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			// The service does not exist in the backend, removing it from local state.
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read service: %s", err))
+		return
+	}
+
+	// Only the roles known to the state are checked, roles assigned outside Terraform are not detected.
+	var roleIDs []types.String
+	for _, roleID := range state.RoleIDs {
+		assigned, err := r.isRoleAssigned(ctx, roleID.ValueString(), response.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read assignments of role [%s]: %s", roleID.ValueString(), err))
+			return
+		}
+		if assigned {
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+	if state.RoleIDs != nil && roleIDs == nil {
+		roleIDs = []types.String{}
+	}
+
+	state.ID = types.StringValue(response.ID)
+	state.Name = types.StringValue(response.Name)
+	state.Description = utils.StringOrNullPtr(response.Description)
+	state.RoleIDs = roleIDs
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BiotServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TerraformService
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secretKey, diags := configSecretKey(ctx, req.Config.GetAttribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := r.client.CreateService(ctx, serviceRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to create service: %s", err))
+		return
+	}
+
+	// The service exists from here on: keep it in state even if the next steps fail, Terraform then marks it as tainted.
+	state := plan
+	state.ID = types.StringValue(response.ID)
+	state.SecretKey = types.StringNull()
+	state.RoleIDs = nil
+	if plan.RoleIDs != nil {
+		state.RoleIDs = []types.String{}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	err = r.client.SetServiceSecret(ctx, response.ID, secretKey)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to set the secret key of service [%s]: %s", response.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, appliedSecretKeyHashPrivateKey, secretKeyHash(secretKey))...)
+
+	state.RoleIDs, diags = r.updateRoles(ctx, response.ID, state.RoleIDs, plan.RoleIDs)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BiotServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TerraformService
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+
+	if !plan.Name.Equal(state.Name) || !plan.Description.Equal(state.Description) {
+		response, err := r.client.UpdateService(ctx, id, serviceRequest(plan))
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to update service: %s", err))
+			return
+		}
+		state.Name = types.StringValue(response.Name)
+		state.Description = utils.StringOrNullPtr(response.Description)
+	}
+
+	if !plan.RotationTrigger.Equal(state.RotationTrigger) {
+		secretKey, diags := configSecretKey(ctx, req.Config.GetAttribute)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		err := r.client.SetServiceSecret(ctx, id, secretKey)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to rotate the secret key of service [%s]: %s", id, err))
+			return
+		}
+		state.RotationTrigger = plan.RotationTrigger
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, appliedSecretKeyHashPrivateKey, secretKeyHash(secretKey))...)
+	}
+
+	var diags diag.Diagnostics
+	state.RoleIDs, diags = r.updateRoles(ctx, id, state.RoleIDs, plan.RoleIDs)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Warns when the configured secret key is not the applied one and rotation_trigger does not change, so the new key is
+// not applied: e.g. the secret store the service reads its key from would get a key the service was never given.
+// Services imported or created before the hash was kept are not checked.
+func (r *BiotServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state TerraformService
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !plan.RotationTrigger.Equal(state.RotationTrigger) {
+		return
+	}
+
+	appliedHash, diags := req.Private.GetKey(ctx, appliedSecretKeyHashPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if len(appliedHash) == 0 {
+		return
+	}
+
+	var secretKey types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_key_wo"), &secretKey)...)
+	if secretKey.IsNull() || secretKey.IsUnknown() {
+		return
+	}
+
+	if !bytes.Equal(secretKeyHash(secretKey.ValueString()), appliedHash) {
+		resp.Diagnostics.AddAttributeWarning(path.Root("secret_key_wo"), "Secret key is not applied", fmt.Sprintf(
+			"secret_key_wo of service [%s] is not the secret key that was applied, and rotation_trigger does not change, so the service keeps "+
+				"its current key. Change rotation_trigger to apply the new key, and give biot_service_secret the same rotation_trigger and service_name.",
+			state.Name.ValueString(),
+		))
+	}
+}
+
+func (r *BiotServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TerraformService
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteService(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to delete service: %s", err))
+	}
+}
+
+// The roles of an imported service are not read, see Read. Assigned roles must be imported with biot_role_assignment instead.
+func (r *BiotServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Assigns the planned roles that are not assigned yet and unassigns the removed ones.
+// Returns the roles assigned when it stopped, so a failure leaves an accurate state.
+func (r *BiotServiceResource) updateRoles(ctx context.Context, serviceID string, stateRoleIDs []types.String, planRoleIDs []types.String) ([]types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	assigned := slices.Clone(stateRoleIDs)
+
+	for _, roleID := range stateRoleIDs {
+		if slices.Contains(planRoleIDs, roleID) {
+			continue
+		}
+		err := r.client.UnassignRole(ctx, roleID.ValueString(), serviceID)
+		if err != nil && !errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			diags.AddError("API Error", fmt.Sprintf("Failed to unassign role [%s] from service [%s]: %s", roleID.ValueString(), serviceID, err))
+			return assigned, diags
+		}
+		assigned = slices.DeleteFunc(assigned, func(id types.String) bool { return id.Equal(roleID) })
+	}
+
+	for _, roleID := range planRoleIDs {
+		if slices.Contains(stateRoleIDs, roleID) {
+			continue
+		}
+		err := r.client.AssignRole(ctx, roleID.ValueString(), api.RoleAssignment{
+			PrincipalID:   serviceID,
+			PrincipalType: api.PrincipalTypeService,
+		})
+		if err != nil {
+			diags.AddError("API Error", fmt.Sprintf("Failed to assign role [%s] to service [%s]: %s", roleID.ValueString(), serviceID, err))
+			return assigned, diags
+		}
+		assigned = append(assigned, roleID)
+	}
+
+	if planRoleIDs == nil && len(assigned) == 0 {
+		return nil, diags
+	}
+	return assigned, diags
+}
+
+func (r *BiotServiceResource) isRoleAssigned(ctx context.Context, roleID string, serviceID string) (bool, error) {
+	assignments, err := r.client.GetRoleAssignments(ctx, roleID)
+	if err != nil {
+		if errors.Is(err, api.SpecificErrorCodes.NotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return slices.ContainsFunc(assignments.Data, func(assignment api.RoleAssignment) bool {
+		return assignment.PrincipalID == serviceID
+	}), nil
+}
+
+// Write-only attributes are null in the plan, the secret key is only available in the configuration.
+func configSecretKey(ctx context.Context, getAttribute func(context.Context, path.Path, interface{}) diag.Diagnostics) (string, diag.Diagnostics) {
+	var secretKey types.String
+	diags := getAttribute(ctx, path.Root("secret_key_wo"), &secretKey)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	if secretKey.IsNull() || secretKey.IsUnknown() || secretKey.ValueString() == "" {
+		diags.AddAttributeError(path.Root("secret_key_wo"), "Missing secret key", "The secret key of the service must be set in the configuration.")
+	}
+	return secretKey.ValueString(), diags
+}
+
+// Kept in private state instead of the key, as a JSON string (private state values are JSON). A secret key is long
+// and random, so the hash does not reveal it.
+func secretKeyHash(secretKey string) []byte {
+	hash := sha256.Sum256([]byte(secretKey))
+	return []byte(`"` + hex.EncodeToString(hash[:]) + `"`)
+}
+
+func serviceRequest(plan TerraformService) api.ServiceRequest {
+	return api.ServiceRequest{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueStringPointer(),
+	}
+}
@@ -0,0 +1,112 @@
+package ums
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+func NewPermissionsDataSource() datasource.DataSource {
+	return &BiotPermissionsDataSource{}
+}
+
+// Lists the permission catalog of UMS, the permissions that can be given to a biot_role.
+type BiotPermissionsDataSource struct {
+	client *api.APIClient
+}
+
+type TerraformPermissions struct {
+	Prefix      types.String          `tfsdk:"prefix"`
+	Names       []types.String        `tfsdk:"names"`
+	Permissions []TerraformPermission `tfsdk:"permissions"`
+}
+
+type TerraformPermission struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *BiotPermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "biot_permissions"
+}
+
+func (d *BiotPermissionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BiotPermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The permission catalog of UMS: the permissions that can be given to a `biot_role`, sorted by name.",
+		Attributes: map[string]schema.Attribute{
+			"prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "When set, only the permissions whose name starts with the prefix are listed.",
+			},
+			"names": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the permissions.",
+			},
+			"permissions": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":        schema.StringAttribute{Computed: true},
+						"description": schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BiotPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config TerraformPermissions
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	catalog, err := d.client.GetPermissions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Failed to read the permission catalog: %s", err))
+		return
+	}
+
+	permissions := slices.Clone(catalog.Data)
+	slices.SortFunc(permissions, func(a, b api.Permission) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	config.Names = []types.String{}
+	config.Permissions = []TerraformPermission{}
+	for _, permission := range permissions {
+		if !strings.HasPrefix(permission.Name, config.Prefix.ValueString()) {
+			continue
+		}
+		config.Names = append(config.Names, types.StringValue(permission.Name))
+		config.Permissions = append(config.Permissions, TerraformPermission{
+			Name:        types.StringValue(permission.Name),
+			Description: types.StringValue(permission.Description),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
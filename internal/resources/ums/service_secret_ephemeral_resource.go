@@ -0,0 +1,144 @@
+package ums
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"biot.com/terraform-provider-biot-gen2/internal/api"
+)
+
+const (
+	defaultServiceSecretLength = 64
+	minServiceSecretLength     = 32
+
+	serviceSecretAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+func NewServiceSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &BiotServiceSecretEphemeralResource{}
+}
+
+// The secret key for a biot_service. Being ephemeral, the key is never stored in the plan or state. It is derived from
+// the service name and the rotation_trigger of the service (and the secret key of the provider), so every run returns
+// the same key until the trigger changes, which is exactly when biot_service applies it.
+type BiotServiceSecretEphemeralResource struct {
+	client *api.APIClient
+}
+
+type TerraformServiceSecret struct {
+	ServiceName     types.String `tfsdk:"service_name"`
+	RotationTrigger types.String `tfsdk:"rotation_trigger"`
+	Length          types.Int64  `tfsdk:"length"`
+	SecretKey       types.String `tfsdk:"secret_key"`
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = &BiotServiceSecretEphemeralResource{}
+
+func (e *BiotServiceSecretEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "biot_service_secret"
+}
+
+func (e *BiotServiceSecretEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.APIClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type", "Expected *api.APIClient")
+		return
+	}
+
+	e.client = client
+}
+
+func (e *BiotServiceSecretEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The secret key for `biot_service.secret_key_wo`. Requires Terraform 1.10 or later.\n\n" +
+			"The key is derived from `service_name` and `rotation_trigger` (and the secret key the provider logs in with), " +
+			"so it stays the same from run to run and changes only with them. Pass the same values as the `biot_service`: " +
+			"the key changes exactly when the service applies a new one, and the secret store the service reads it from can be written " +
+			"on every run.",
+		Attributes: map[string]schema.Attribute{
+			"service_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The `name` of the `biot_service`, so services with the same `rotation_trigger` get different keys.",
+			},
+			"rotation_trigger": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The `rotation_trigger` of the `biot_service`, if it has one. Changing it returns a new key.",
+			},
+			"length": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The length of the secret key, at least %d. Defaults to %d.", minServiceSecretLength, defaultServiceSecretLength),
+			},
+			"secret_key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (e *BiotServiceSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config TerraformServiceSecret
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	length := int64(defaultServiceSecretLength)
+	if !config.Length.IsNull() {
+		length = config.Length.ValueInt64()
+	}
+	if length < minServiceSecretLength {
+		resp.Diagnostics.AddAttributeError(path.Root("length"), "Secret key too short", fmt.Sprintf("The secret key must be at least %d characters long, got %d.", minServiceSecretLength, length))
+		return
+	}
+
+	secretKey, err := deriveServiceSecret(e.client, config.ServiceName.ValueString(), config.RotationTrigger.ValueString(), int(length))
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to derive secret key", err.Error())
+		return
+	}
+
+	config.Length = types.Int64Value(length)
+	config.SecretKey = types.StringValue(secretKey)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, config)...)
+}
+
+type keyDeriver interface {
+	DeriveKey(info string, keyLength int) ([]byte, error)
+}
+
+// Maps derived bytes to the alphabet, skipping the bytes that would make some characters more likely than others.
+// Twice the bytes needed are derived, running out of them is practically impossible.
+func deriveServiceSecret(deriver keyDeriver, serviceName string, rotationTrigger string, length int) (string, error) {
+	info := strings.Join([]string{"biot_service_secret", serviceName, rotationTrigger}, "\x00")
+	derived, err := deriver.DeriveKey(info, 2*length)
+	if err != nil {
+		return "", err
+	}
+
+	alphabetSize := len(serviceSecretAlphabet)
+	limit := 256 - 256%alphabetSize
+
+	var secret strings.Builder
+	for _, b := range derived {
+		if int(b) >= limit {
+			continue
+		}
+		secret.WriteByte(serviceSecretAlphabet[int(b)%alphabetSize])
+		if secret.Len() == length {
+			return secret.String(), nil
+		}
+	}
+	return "", errors.New("not enough key material was derived")
+}
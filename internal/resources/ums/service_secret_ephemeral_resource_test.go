@@ -0,0 +1,46 @@
+package ums
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"strings"
+	"testing"
+)
+
+type testKeyDeriver string
+
+func (d testKeyDeriver) DeriveKey(info string, keyLength int) ([]byte, error) {
+	return hkdf.Key(sha256.New, []byte(d), nil, info, keyLength)
+}
+
+func TestDeriveServiceSecret(t *testing.T) {
+	derive := func(deriver testKeyDeriver, serviceName string, rotationTrigger string) string {
+		t.Helper()
+		secret, err := deriveServiceSecret(deriver, serviceName, rotationTrigger, defaultServiceSecretLength)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return secret
+	}
+
+	secret := derive("provider-secret", "plugin", "2026-10")
+	if len(secret) != defaultServiceSecretLength {
+		t.Errorf("got a secret key of length %d, want %d", len(secret), defaultServiceSecretLength)
+	}
+	if strings.Trim(secret, serviceSecretAlphabet) != "" {
+		t.Errorf("secret key [%s] has characters that are not in the alphabet", secret)
+	}
+
+	if again := derive("provider-secret", "plugin", "2026-10"); again != secret {
+		t.Error("the same rotation trigger returned another secret key")
+	}
+	if rotated := derive("provider-secret", "plugin", "2026-11"); rotated == secret {
+		t.Error("a new rotation trigger returned the same secret key")
+	}
+	if other := derive("provider-secret", "integration", "2026-10"); other == secret {
+		t.Error("another service returned the same secret key")
+	}
+	if other := derive("other-provider-secret", "plugin", "2026-10"); other == secret {
+		t.Error("another provider secret key returned the same secret key")
+	}
+}
@@ -3,8 +3,11 @@ package utils
 import (
 	"context"
 	"encoding/json"
+	"maps"
 	"math/big"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -77,6 +80,14 @@ func ConvertStringList(in []string) []types.String {
 	return out
 }
 
+func ConvertStringListValue(in []string) types.List {
+	elements := []attr.Value{}
+	for _, v := range in {
+		elements = append(elements, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
 // from types to native
 
 func Float64OrNilPtr(n types.Number) *float64 {
@@ -132,3 +143,23 @@ func ConvertTerraformStringList(in []types.String) []string {
 	}
 	return out
 }
+
+func ConvertTerraformStringListValue(in types.List) []string {
+	if in.IsNull() || in.IsUnknown() {
+		return nil
+	}
+	out := []string{}
+	for _, v := range in.Elements() {
+		if s, ok := v.(types.String); ok && !s.IsNull() && !s.IsUnknown() {
+			out = append(out, s.ValueString())
+		} else {
+			out = append(out, "")
+		}
+	}
+	return out
+}
+
+// Map keys in a stable order, so requests built from maps are deterministic.
+func SortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}